@@ -0,0 +1,58 @@
+// Package cloudns account functions
+package cloudns
+
+import (
+	"context"
+	"errors"
+
+	"github.com/tidwall/gjson"
+)
+
+// Verify checks the credentials against the API, call it at startup to fail early
+// on a wrong password or an IP address that is not whitelisted for the API user
+func (a Apiaccess) Verify(ctx context.Context) error {
+	resp, err := a.logincheck(ctx)
+	if err != nil {
+		return err
+	}
+	errmsg, isapierr := checkapierr(resp.Body())
+	if isapierr {
+		return errors.New(errmsg)
+	}
+	return nil
+}
+
+// MyIP returns the IP address the API sees requests coming from,
+// which is the address that has to be whitelisted for the API user
+func (a Apiaccess) MyIP(ctx context.Context) (string, error) {
+	resp, err := a.myip(ctx)
+	if err != nil {
+		return "", err
+	}
+	errmsg, isapierr := checkapierr(resp.Body())
+	if isapierr {
+		return "", errors.New(errmsg)
+	}
+	ip := gjson.GetBytes(resp.Body(), "ip")
+	if !ip.Exists() {
+		return "", errors.New("no ip in response")
+	}
+	return ip.String(), nil
+}
+
+// Balance returns the funds available on the account, only the main user can read it
+func (a Apiaccess) Balance(ctx context.Context) (float64, error) {
+	resp, err := a.balance(ctx)
+	if err != nil {
+		return 0, err
+	}
+	errmsg, isapierr := checkapierr(resp.Body())
+	if isapierr {
+		return 0, errors.New(errmsg)
+	}
+	funds := gjson.GetBytes(resp.Body(), "funds")
+	if !funds.Exists() {
+		return 0, errors.New("no funds in response")
+	}
+	return funds.Float(), nil
+}
@@ -2,25 +2,35 @@
 package cloudns
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-resty/resty/v2"
 )
 
-const (
+var (
 	apiurl = "https://api.cloudns.net"
 )
 
 func apireq(path string, body interface{}) (*resty.Response, error) {
+	return apireqctx(context.Background(), path, body)
+}
+
+func apireqctx(ctx context.Context, path string, body interface{}) (*resty.Response, error) {
+	if err := checkauth(body); err != nil {
+		return nil, err
+	}
 	fullurl := strings.Join([]string{apiurl, path}, "")
 	client := resty.New()
 	client.R().SetHeader("Content-Type", "application/json")
 	client.R().SetHeader("Accept", "application/json")
 	client.R().SetHeader("User-Agent", "github.com/ClouDNS/cloudns-go")
-	return client.R().SetBody(body).Post(fullurl)
+	return client.R().SetContext(ctx).SetBody(body).Post(fullurl)
 }
 
 type apierr struct {
@@ -29,34 +39,101 @@ type apierr struct {
 }
 
 type retzone struct {
-	Domain string `json:"name"`
-	Ztype  string `json:"type"`
-	Master string `json:"master-ip,omitempty"`
-	Ns     string `json:"ns,omitempty"`
+	Domain string  `json:"name"`
+	Ztype  string  `json:"type"`
+	Master string  `json:"master-ip,omitempty"`
+	Ns     string  `json:"ns,omitempty"`
+	Group  string  `json:"group,omitempty"`
+	Status flexint `json:"status"`
+}
+
+// checkauth validates the auth fields of a request struct before it is sent,
+// all request structs share the json field names of Apiaccess
+func checkauth(body interface{}) error {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	var a Apiaccess
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
+	return a.Validate()
 }
 
 func checkapierr(d []byte) (string, bool) {
+	if err := apierror(d); err != nil {
+		return err.(*APIError).Description, true
+	}
+	return "", false
+}
+
+// apierror returns the error status of a response as *APIError, or nil
+func apierror(d []byte) error {
 	var status apierr
 	err := json.Unmarshal(d, &status)
 	if err == nil && status.Status != "Success" && (apierr{}) != status {
-		return status.Desc, true
+		return &APIError{Status: status.Status, Description: status.Desc}
 	}
-	return "", false
+	return nil
+}
+
+// unmarshallist reads a list that the API returns either as json array
+// or as object keyed by id, like records.json does
+func unmarshallist[T any](d []byte) ([]T, error) {
+	var list []T
+	if err := json.Unmarshal(d, &list); err == nil {
+		return list, nil
+	}
+	var keyed map[string]T
+	if err := json.Unmarshal(d, &keyed); err != nil {
+		return nil, err
+	}
+	keys := make([]string, 0, len(keyed))
+	for k := range keyed {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		list = append(list, keyed[k])
+	}
+	return list, nil
 }
 
-func (c Apiaccess) logincheck() (*resty.Response, error) {
+func (c Apiaccess) logincheck(ctx context.Context) (*resty.Response, error) {
 	const path = "/dns/login.json"
-	return apireq(path, c)
+	return apireqctx(ctx, path, c)
 }
 
-func (c Apiaccess) availablettl() (*resty.Response, error) {
+func (c Apiaccess) myip(ctx context.Context) (*resty.Response, error) {
+	const path = "/ip/get-my-ip.json"
+	return apireqctx(ctx, path, c)
+}
+
+func (c Apiaccess) balance(ctx context.Context) (*resty.Response, error) {
+	const path = "/account/get-balance.json"
+	return apireqctx(ctx, path, c)
+}
+
+func (c Apiaccess) availablettl(ctx context.Context) (*resty.Response, error) {
 	const path = "/dns/get-available-ttl.json"
-	return apireq(path, c)
+	return apireqctx(ctx, path, c)
+}
+
+func (c Apiaccess) zonesstats(ctx context.Context) (*resty.Response, error) {
+	const path = "/dns/get-zones-stats.json"
+	return apireqctx(ctx, path, c)
+}
+
+type retusage struct {
+	Count flexint `json:"count"`
+	Limit flexint `json:"limit"`
 }
 
 type nslist struct {
 	Authid       int    `json:"auth-id,omitempty"`
 	Subauthid    int    `json:"sub-auth-id,omitempty"`
+	Subauthuser  string `json:"sub-auth-user,omitempty"`
 	Authpassword string `json:"auth-password"`
 	DetailedInfo int    `json:"detailed-info,ommitempty"`
 }
@@ -67,35 +144,43 @@ func (n nslist) lsns() (*resty.Response, error) {
 }
 
 type retns struct {
-	Type string `json:"type"`
-	Name string `json:"name"`
+	ID            string  `json:"id,omitempty"`
+	Type          string  `json:"type"`
+	Name          string  `json:"name"`
+	Ip4           string  `json:"ip4"`
+	Ip6           string  `json:"ip6"`
+	Location      string  `json:"location"`
+	LocationCc    string  `json:"location_cc"`
+	DdosProtected flexint `json:"ddos_protected"`
 }
 
 type rectypes struct {
 	Authid       int    `json:"auth-id,omitempty"`
 	Subauthid    int    `json:"sub-auth-id,omitempty"`
+	Subauthuser  string `json:"sub-auth-user,omitempty"`
 	Authpassword string `json:"auth-password"`
 	Ztype        string `json:"zone-type"`
 	Master       string `json:"master-ip,omitempty"`
 }
 
-func (r rectypes) availabletype() (*resty.Response, error) {
+func (r rectypes) availabletype(ctx context.Context) (*resty.Response, error) {
 	const path = "/dns/get-available-record-types.json"
-	return apireq(path, r)
+	return apireqctx(ctx, path, r)
 }
 
 type reclist struct {
 	Authid       int    `json:"auth-id,omitempty"`
 	Subauthid    int    `json:"sub-auth-id,omitempty"`
+	Subauthuser  string `json:"sub-auth-user,omitempty"`
 	Authpassword string `json:"auth-password"`
 	Domain       string `json:"domain-name"`
 	Host         string `json:"host,omitempty"`
 	Rtype        string `json:"type,omitempty"`
 }
 
-func (r reclist) lsrec() (*resty.Response, error) {
+func (r reclist) lsrec(ctx context.Context) (*resty.Response, error) {
 	const path = "/dns/records.json"
-	return apireq(path, r)
+	return apireqctx(ctx, path, r)
 }
 
 type retrec struct {
@@ -111,9 +196,9 @@ type retrec struct {
 	FrameTitle         string  `json:"frame-title,omitempty"`
 	FrameKeywords      string  `json:"frame-keywords,omitempty"`
 	FrameDescription   string  `json:"frame-description,omitempty"`
-	MobileMeta         int     `json:"mobile-meta,omitempty"`
-	SavePath           int     `json:"save-path,omitempty"`
-	RedirectType       int     `json:"redirect-type,omitempty"`
+	MobileMeta         flexint `json:"mobile-meta,omitempty"`
+	SavePath           flexint `json:"save-path,omitempty"`
+	RedirectType       flexint `json:"redirect-type,omitempty"`
 	Mail               string  `json:"mail,omitempty"`
 	Txt                string  `json:"txt,omitempty"`
 	Algorithm          string  `json:"algorithm,omitempty"`
@@ -160,6 +245,7 @@ type retrec struct {
 type zonelist struct {
 	Authid       int    `json:"auth-id,omitempty"`
 	Subauthid    int    `json:"sub-auth-id,omitempty"`
+	Subauthuser  string `json:"sub-auth-user,omitempty"`
 	Authpassword string `json:"auth-password"`
 	Page         int    `json:"page"`
 	Hits         int    `json:"rows-per-page"`
@@ -172,11 +258,54 @@ func (z zonelist) lszone() (*resty.Response, error) {
 	return apireq(path, z)
 }
 
+type zonegroup struct {
+	Authid       int    `json:"auth-id,omitempty"`
+	Subauthid    int    `json:"sub-auth-id,omitempty"`
+	Subauthuser  string `json:"sub-auth-user,omitempty"`
+	Authpassword string `json:"auth-password"`
+	ID           int    `json:"group-id,omitempty"`
+	Name         string `json:"name,omitempty"`
+	NewName      string `json:"new-name,omitempty"`
+	Domain       string `json:"domain-name,omitempty"`
+}
+
+type retgroup struct {
+	ID   flexint `json:"id"`
+	Name string  `json:"name"`
+}
+
+func (g zonegroup) list() (*resty.Response, error) {
+	const path = "/dns/list-groups.json"
+	return apireq(path, g)
+}
+
+func (g zonegroup) add() (*resty.Response, error) {
+	const path = "/dns/add-group.json"
+	return apireq(path, g)
+}
+
+func (g zonegroup) rename() (*resty.Response, error) {
+	const path = "/dns/rename-group.json"
+	return apireq(path, g)
+}
+
+func (g zonegroup) destroy() (*resty.Response, error) {
+	const path = "/dns/delete-group.json"
+	return apireq(path, g)
+}
+
+func (g zonegroup) change() (*resty.Response, error) {
+	const path = "/dns/change-group.json"
+	return apireq(path, g)
+}
+
 type createrec struct {
 	Authid             int     `json:"auth-id,omitempty"`
 	Subauthid          int     `json:"sub-auth-id,omitempty"`
+	Subauthuser        string  `json:"sub-auth-user,omitempty"`
 	Authpassword       string  `json:"auth-password"`
-	Domain             string  `json:"domain-name"`
+	Domain             string  `json:"domain-name,omitempty"`
+	TemplateID         int     `json:"template-id,omitempty"`
 	Rtype              string  `json:"record-type"`
 	TTL                int     `json:"ttl"`
 	Host               string  `json:"host"`
@@ -188,8 +317,8 @@ type createrec struct {
 	FrameTitle         string  `json:"frame-title,omitempty"`
 	FrameKeywords      string  `json:"frame-keywords,omitempty"`
 	FrameDescription   string  `json:"frame-description,omitempty"`
-	MobileMeta         int     `json:"mobile-meta,omitempty"`
-	SavePath           int     `json:"save-path,omitempty"`
+	MobileMeta         *int    `json:"mobile-meta,omitempty"`
+	SavePath           *int    `json:"save-path,omitempty"`
 	RedirectType       int     `json:"redirect-type,omitempty"`
 	Mail               string  `json:"mail,omitempty"`
 	Txt                string  `json:"txt,omitempty"`
@@ -238,23 +367,31 @@ func (r createrec) read() (*resty.Response, error) {
 	listrec := reclist{
 		Authid:       r.Authid,
 		Subauthid:    r.Subauthid,
+		Subauthuser:  r.Subauthuser,
 		Authpassword: r.Authpassword,
 		Rtype:        r.Rtype,
 		Host:         r.Host,
 	}
-	return listrec.lsrec()
+	return listrec.lsrec(context.Background())
 }
 
-func (r createrec) create() (*resty.Response, error) {
+func (r createrec) create(ctx context.Context) (*resty.Response, error) {
 	const path = "/dns/add-record.json"
+	return apireqctx(ctx, path, r)
+}
+
+func (r createrec) createtemplate() (*resty.Response, error) {
+	const path = "/dns/add-template-record.json"
 	return apireq(path, r)
 }
 
 type updaterec struct {
 	Authid             int     `json:"auth-id,omitempty"`
 	Subauthid          int     `json:"sub-auth-id,omitempty"`
+	Subauthuser        string  `json:"sub-auth-user,omitempty"`
 	Authpassword       string  `json:"auth-password"`
-	Domain             string  `json:"domain-name"`
+	Domain             string  `json:"domain-name,omitempty"`
+	TemplateID         int     `json:"template-id,omitempty"`
 	Rid                int     `json:"record-id"`
 	TTL                int     `json:"ttl"`
 	Host               string  `json:"host"`
@@ -266,8 +403,8 @@ type updaterec struct {
 	FrameTitle         string  `json:"frame-title,omitempty"`
 	FrameKeywords      string  `json:"frame-keywords,omitempty"`
 	FrameDescription   string  `json:"frame-description,omitempty"`
-	MobileMeta         int     `json:"mobile-meta,omitempty"`
-	SavePath           int     `json:"save-path,omitempty"`
+	MobileMeta         *int    `json:"mobile-meta,omitempty"`
+	SavePath           *int    `json:"save-path,omitempty"`
 	RedirectType       int     `json:"redirect-type,omitempty"`
 	Mail               string  `json:"mail,omitempty"`
 	Txt                string  `json:"txt,omitempty"`
@@ -312,19 +449,77 @@ type updaterec struct {
 	OS                 string  `json:"os,omitempty"`
 }
 
-func (r updaterec) update() (*resty.Response, error) {
+func (r updaterec) update(ctx context.Context) (*resty.Response, error) {
 	const path = "/dns/mod-record.json"
-	return apireq(path, r)
+	return apireqctx(ctx, path, r)
 }
 
-func (r updaterec) destroy() (*resty.Response, error) {
+func (r updaterec) destroy(ctx context.Context) (*resty.Response, error) {
 	const path = "/dns/delete-record.json"
+	return apireqctx(ctx, path, r)
+}
+
+func (r updaterec) updatetemplate() (*resty.Response, error) {
+	const path = "/dns/mod-template-record.json"
+	return apireq(path, r)
+}
+
+func (r updaterec) destroytemplate() (*resty.Response, error) {
+	const path = "/dns/delete-template-record.json"
 	return apireq(path, r)
 }
 
+type dnstemplate struct {
+	Authid       int    `json:"auth-id,omitempty"`
+	Subauthid    int    `json:"sub-auth-id,omitempty"`
+	Subauthuser  string `json:"sub-auth-user,omitempty"`
+	Authpassword string `json:"auth-password"`
+	ID           int    `json:"template-id,omitempty"`
+	Name         string `json:"name,omitempty"`
+	Domain       string `json:"domain-name,omitempty"`
+	Page         int    `json:"page,omitempty"`
+	Hits         int    `json:"rows-per-page,omitempty"`
+}
+
+type rettemplate struct {
+	ID   flexint `json:"id"`
+	Name string  `json:"name"`
+}
+
+func (t dnstemplate) list() (*resty.Response, error) {
+	const path = "/dns/list-templates.json"
+	return apireq(path, t)
+}
+
+func (t dnstemplate) add() (*resty.Response, error) {
+	const path = "/dns/add-template.json"
+	return apireq(path, t)
+}
+
+func (t dnstemplate) rename() (*resty.Response, error) {
+	const path = "/dns/edit-template.json"
+	return apireq(path, t)
+}
+
+func (t dnstemplate) destroy() (*resty.Response, error) {
+	const path = "/dns/delete-template.json"
+	return apireq(path, t)
+}
+
+func (t dnstemplate) records() (*resty.Response, error) {
+	const path = "/dns/template-records.json"
+	return apireq(path, t)
+}
+
+func (t dnstemplate) apply() (*resty.Response, error) {
+	const path = "/dns/apply-template.json"
+	return apireq(path, t)
+}
+
 type createzone struct {
 	Authid       int      `json:"auth-id,omitempty"`
 	Subauthid    int      `json:"sub-auth-id,omitempty"`
+	Subauthuser  string   `json:"sub-auth-user,omitempty"`
 	Authpassword string   `json:"auth-password"`
 	Domain       string   `json:"domain-name"`
 	Ztype        string   `json:"zone-type"`
@@ -336,6 +531,7 @@ func (z createzone) read() (*resty.Response, error) {
 	listzone := zonelist{
 		Authid:       z.Authid,
 		Subauthid:    z.Subauthid,
+		Subauthuser:  z.Subauthuser,
 		Authpassword: z.Authpassword,
 		Page:         1,
 		Hits:         10,
@@ -352,6 +548,7 @@ func (z createzone) create() (*resty.Response, error) {
 type zupdate struct {
 	Authid       int    `json:"auth-id,omitempty"`
 	Subauthid    int    `json:"sub-auth-id,omitempty"`
+	Subauthuser  string `json:"sub-auth-user,omitempty"`
 	Authpassword string `json:"auth-password"`
 	Domain       string `json:"domain-name"`
 }
@@ -362,6 +559,7 @@ func (z createzone) update() (*resty.Response, error) {
 	up := zupdate{
 		Authid:       z.Authid,
 		Subauthid:    z.Subauthid,
+		Subauthuser:  z.Subauthuser,
 		Authpassword: z.Authpassword,
 		Domain:       z.Domain,
 	}
@@ -373,116 +571,359 @@ func (z createzone) destroy() (*resty.Response, error) {
 	rm := zupdate{
 		Authid:       z.Authid,
 		Subauthid:    z.Subauthid,
+		Subauthuser:  z.Subauthuser,
 		Authpassword: z.Authpassword,
 		Domain:       z.Domain,
 	}
 	return apireq(path, rm)
 }
 
+type clouddomain struct {
+	Authid       int    `json:"auth-id,omitempty"`
+	Subauthid    int    `json:"sub-auth-id,omitempty"`
+	Subauthuser  string `json:"sub-auth-user,omitempty"`
+	Authpassword string `json:"auth-password"`
+	Domain       string `json:"domain-name"`
+	CloudDomain  string `json:"cloud-domain-name,omitempty"`
+}
+
+func (c clouddomain) list() (*resty.Response, error) {
+	const path = "/dns/list-cloud-domains.json"
+	return apireq(path, c)
+}
+
+func (c clouddomain) add() (*resty.Response, error) {
+	const path = "/dns/add-cloud-domain.json"
+	return apireq(path, c)
+}
+
+func (c clouddomain) destroy() (*resty.Response, error) {
+	const path = "/dns/delete-cloud-domain.json"
+	return apireq(path, c)
+}
+
+func (c clouddomain) setmaster() (*resty.Response, error) {
+	const path = "/dns/set-master-cloud-domain.json"
+	return apireq(path, c)
+}
+
+type copyrecords struct {
+	Authid        int    `json:"auth-id,omitempty"`
+	Subauthid     int    `json:"sub-auth-id,omitempty"`
+	Subauthuser   string `json:"sub-auth-user,omitempty"`
+	Authpassword  string `json:"auth-password"`
+	Domain        string `json:"domain-name"`
+	FromDomain    string `json:"from-domain"`
+	DeleteCurrent int    `json:"delete-current-records"`
+}
+
+func (c copyrecords) copy(ctx context.Context) (*resty.Response, error) {
+	const path = "/dns/copy-records.json"
+	return apireqctx(ctx, path, c)
+}
+
+type mailforward struct {
+	Authid       int    `json:"auth-id,omitempty"`
+	Subauthid    int    `json:"sub-auth-id,omitempty"`
+	Subauthuser  string `json:"sub-auth-user,omitempty"`
+	Authpassword string `json:"auth-password"`
+	Domain       string `json:"domain-name,omitempty"`
+	ID           string `json:"mail-forward-id,omitempty"`
+	Box          string `json:"box,omitempty"`
+	Host         string `json:"host,omitempty"`
+	Destination  string `json:"destination,omitempty"`
+	Status       *int   `json:"status,omitempty"`
+}
+
+type retmailforward struct {
+	ID          json.Number `json:"id"`
+	Box         string      `json:"box"`
+	Host        string      `json:"host"`
+	Destination string      `json:"destination"`
+	Status      flexint     `json:"status"`
+}
+
+func (m mailforward) list() (*resty.Response, error) {
+	const path = "/dns/mail-forwards.json"
+	return apireq(path, m)
+}
+
+func (m mailforward) add() (*resty.Response, error) {
+	const path = "/dns/add-mail-forward.json"
+	return apireq(path, m)
+}
+
+func (m mailforward) modify() (*resty.Response, error) {
+	const path = "/dns/modify-mail-forward.json"
+	return apireq(path, m)
+}
+
+func (m mailforward) destroy() (*resty.Response, error) {
+	const path = "/dns/delete-mail-forward.json"
+	return apireq(path, m)
+}
+
+func (m mailforward) changestatus() (*resty.Response, error) {
+	const path = "/dns/modify-mail-forward-status.json"
+	return apireq(path, m)
+}
+
+func (m mailforward) stats() (*resty.Response, error) {
+	const path = "/dns/get-mail-forwards-stats.json"
+	return apireq(path, m)
+}
+
+type zonestatus struct {
+	Authid       int    `json:"auth-id,omitempty"`
+	Subauthid    int    `json:"sub-auth-id,omitempty"`
+	Subauthuser  string `json:"sub-auth-user,omitempty"`
+	Authpassword string `json:"auth-password"`
+	Domain       string `json:"domain-name"`
+	Status       int    `json:"status"`
+}
+
+func (z zonestatus) change() (*resty.Response, error) {
+	const path = "/dns/change-status.json"
+	return apireq(path, z)
+}
+
+func (z zupdate) refresh() (*resty.Response, error) {
+	const path = "/dns/update-slave-zone.json"
+	return apireq(path, z)
+}
+
+func (z zupdate) transferstatus() (*resty.Response, error) {
+	const path = "/dns/slave-zone-status.json"
+	return apireq(path, z)
+}
+
+func (z zupdate) info(ctx context.Context) (*resty.Response, error) {
+	const path = "/dns/get-zone-info.json"
+	return apireqctx(ctx, path, z)
+}
+
+type zonestats struct {
+	Authid       int    `json:"auth-id,omitempty"`
+	Subauthid    int    `json:"sub-auth-id,omitempty"`
+	Subauthuser  string `json:"sub-auth-user,omitempty"`
+	Authpassword string `json:"auth-password"`
+	Domain       string `json:"domain-name"`
+	Year         int    `json:"year,omitempty"`
+	Month        int    `json:"month,omitempty"`
+	Day          int    `json:"day,omitempty"`
+}
+
+func (z zonestats) hourly(ctx context.Context) (*resty.Response, error) {
+	const path = "/dns/statistics-hourly.json"
+	return apireqctx(ctx, path, z)
+}
+
+func (z zonestats) daily(ctx context.Context) (*resty.Response, error) {
+	const path = "/dns/statistics-daily.json"
+	return apireqctx(ctx, path, z)
+}
+
+func (z zonestats) monthly(ctx context.Context) (*resty.Response, error) {
+	const path = "/dns/statistics-monthly.json"
+	return apireqctx(ctx, path, z)
+}
+
+func (z zonestats) yearly(ctx context.Context) (*resty.Response, error) {
+	const path = "/dns/statistics-yearly.json"
+	return apireqctx(ctx, path, z)
+}
+
+func (z zupdate) recordsstats(ctx context.Context) (*resty.Response, error) {
+	const path = "/dns/get-records-stats.json"
+	return apireqctx(ctx, path, z)
+}
+
+func (z zupdate) isupdated(ctx context.Context) (*resty.Response, error) {
+	const path = "/dns/is-updated.json"
+	return apireqctx(ctx, path, z)
+}
+
+func (z zupdate) updatestatus(ctx context.Context) (*resty.Response, error) {
+	const path = "/dns/update-status.json"
+	return apireqctx(ctx, path, z)
+}
+
+type retupdatestatus struct {
+	Server  string `json:"server"`
+	IP4     string `json:"ip4"`
+	IP6     string `json:"ip6"`
+	Updated bool   `json:"updated"`
+}
+
+type masterserver struct {
+	Authid       int    `json:"auth-id,omitempty"`
+	Subauthid    int    `json:"sub-auth-id,omitempty"`
+	Subauthuser  string `json:"sub-auth-user,omitempty"`
+	Authpassword string `json:"auth-password"`
+	Domain       string `json:"domain-name"`
+	MasterId     string `json:"master-id,omitempty"`
+	Master       string `json:"master-ip,omitempty"`
+}
+
+type retmaster struct {
+	ID     json.Number `json:"id"`
+	Master string      `json:"master-ip"`
+}
+
+func (m masterserver) list() (*resty.Response, error) {
+	const path = "/dns/master-servers.json"
+	return apireq(path, m)
+}
+
+func (m masterserver) add() (*resty.Response, error) {
+	const path = "/dns/add-master-server.json"
+	return apireq(path, m)
+}
+
+func (m masterserver) destroy() (*resty.Response, error) {
+	const path = "/dns/delete-master-server.json"
+	return apireq(path, m)
+}
+
+type zoneip struct {
+	Authid       int    `json:"auth-id,omitempty"`
+	Subauthid    int    `json:"sub-auth-id,omitempty"`
+	Subauthuser  string `json:"sub-auth-user,omitempty"`
+	Authpassword string `json:"auth-password"`
+	Domain       string `json:"domain-name"`
+	ID           string `json:"id,omitempty"`
+	IP           string `json:"ip,omitempty"`
+}
+
+type retzoneip struct {
+	ID     json.Number `json:"id"`
+	Server string      `json:"server"`
+}
+
+func (z zoneip) axfrlist() (*resty.Response, error) {
+	const path = "/dns/axfr-list.json"
+	return apireq(path, z)
+}
+
+func (z zoneip) axfradd() (*resty.Response, error) {
+	const path = "/dns/axfr-add.json"
+	return apireq(path, z)
+}
+
+func (z zoneip) axfrremove() (*resty.Response, error) {
+	const path = "/dns/axfr-remove.json"
+	return apireq(path, z)
+}
+
+func (z zoneip) notifylist() (*resty.Response, error) {
+	const path = "/dns/notify-list.json"
+	return apireq(path, z)
+}
+
+func (z zoneip) notifyadd() (*resty.Response, error) {
+	const path = "/dns/notify-add.json"
+	return apireq(path, z)
+}
+
+func (z zoneip) notifydelete() (*resty.Response, error) {
+	const path = "/dns/notify-delete.json"
+	return apireq(path, z)
+}
+
 type CheckSettings struct {
-	LatencyLimit    string     `json:"latency_limit,omitempty"`
-	Timeout         string     `json:"timeout,omitempty"`
-	HttpRequestType string     `json:"http_request_type,omitempty"`
-	Host            string     `json:"host,omitempty"`
-	Port            CustomPort `json:"port,omitempty"`
-	Path            string     `json:"path,omitempty"`
-	Content         string     `json:"content,omitempty"`
-	QueryResponse   string     `json:"query_response,omitempty"`
-	QueryType       string     `json:"query_type,omitempty"`
+	LatencyLimit    string          `json:"latency_limit,omitempty"`
+	Timeout         string          `json:"timeout,omitempty"`
+	HttpRequestType HttpRequestType `json:"http_request_type,omitempty"`
+	Host            string          `json:"host,omitempty"`
+	Port            CustomPort      `json:"port,omitempty"`
+	Path            string          `json:"path,omitempty"`
+	Content         string          `json:"content,omitempty"`
+	QueryResponse   string          `json:"query_response,omitempty"`
+	QueryType       string          `json:"query_type,omitempty"`
 }
 
 type Failover struct {
-	Domain           string        `json:"domain-name"`
-	RecordId         string        `json:"record-id"`
-	FailoverType     string        `json:"check_type"`
-	CheckSettings    CheckSettings `json:"check_settings"`
-	MonitoringRegion string        `json:"monitoring_region,omitempty"`
-	CheckPeriod      string        `json:"check_period,omitempty"`
-	CheckRegion      string        `json:"checkregion,omitempty"`
-	DownEventHandler string        `json:"down_event_handler"`
-	UpEventHandler   string        `json:"up_event_handler"`
-	MainIP           string        `json:"main_ip"`
-	BackupIp1        string        `json:"backup_ip_1,omitempty"`
-	BackupIp2        string        `json:"backup_ip_2,omitempty"`
-	BackupIp3        string        `json:"backup_ip_3,omitempty"`
-	BackupIp4        string        `json:"backup_ip_4,omitempty"`
-	BackupIp5        string        `json:"backup_ip_5,omitempty"`
-	NotificationMail string        `json:"notification_mail,omitempty"`
+	Domain           string              `json:"domain-name"`
+	RecordId         string              `json:"record-id"`
+	FailoverType     FailoverCheckType   `json:"check_type"`
+	CheckSettings    CheckSettings       `json:"check_settings"`
+	MonitoringRegion MonitoringRegion    `json:"monitoring_region,omitempty"`
+	CheckPeriod      CheckPeriod         `json:"check_period,omitempty"`
+	CheckRegion      string              `json:"checkregion,omitempty"`
+	DownEventHandler FailoverDownHandler `json:"down_event_handler"`
+	UpEventHandler   FailoverUpHandler   `json:"up_event_handler"`
+	MainIP           string              `json:"main_ip"`
+	Backups          []string            `json:"-"` // backup_ip_1, backup_ip_2, ... on the wire
+	NotificationMail string              `json:"notification_mail,omitempty"`
+	// RecordType is the type of the record, A or AAAA. If set Create and Update
+	// check the main and backup IPs against it without another API call.
+	RecordType string `json:"-"`
 }
 
 type ApiFailover struct {
-	Domain           string     `json:"domain-name"`
-	RecordId         string     `json:"record-id"`
-	FailoverType     string     `json:"check_type"`
-	DownEventHandler string     `json:"down_event_handler"`
-	UpEventHandler   string     `json:"up_event_handler"`
-	MainIP           string     `json:"main_ip"`
-	BackupIp1        string     `json:"backup_ip_1,omitempty"`
-	BackupIp2        string     `json:"backup_ip_2,omitempty"`
-	BackupIp3        string     `json:"backup_ip_3,omitempty"`
-	BackupIp4        string     `json:"backup_ip_4,omitempty"`
-	BackupIp5        string     `json:"backup_ip_5,omitempty"`
-	MonitoringRegion string     `json:"monitoring_region,omitempty"`
-	Host             string     `json:"host,omitempty"`
-	Port             CustomPort `json:"port,omitempty"`
-	Path             string     `json:"path,omitempty"`
-	Content          string     `json:"content,omitempty"`
-	QueryType        string     `json:"query_type,omitempty"`
-	QueryResponse    string     `json:"query_response,omitempty"`
-	CheckPeriod      string     `json:"check_period,omitempty"`
-	NotificationMail string     `json:"notification_mail,omitempty"`
-	LatencyLimit     string     `json:"latency_limit,omitempty"`
-	Timeout          string     `json:"timeout,omitempty"`
-	CheckRegion      string     `json:"checkregion,omitempty"`
-	HttpRequestType  string     `json:"http_request_type,omitempty"`
+	Domain           string              `json:"domain-name"`
+	RecordId         string              `json:"record-id"`
+	FailoverType     FailoverCheckType   `json:"check_type"`
+	DownEventHandler FailoverDownHandler `json:"down_event_handler"`
+	UpEventHandler   FailoverUpHandler   `json:"up_event_handler"`
+	MainIP           string              `json:"main_ip"`
+	Backups          []string            `json:"-"` // backup_ip_1, backup_ip_2, ... on the wire
+	MonitoringRegion MonitoringRegion    `json:"monitoring_region,omitempty"`
+	Host             string              `json:"host,omitempty"`
+	Port             CustomPort          `json:"port,omitempty"`
+	Path             string              `json:"path,omitempty"`
+	Content          string              `json:"content,omitempty"`
+	QueryType        string              `json:"query_type,omitempty"`
+	QueryResponse    string              `json:"query_response,omitempty"`
+	CheckPeriod      CheckPeriod         `json:"check_period,omitempty"`
+	NotificationMail string              `json:"notification_mail,omitempty"`
+	LatencyLimit     string              `json:"latency_limit,omitempty"`
+	Timeout          string              `json:"timeout,omitempty"`
+	CheckRegion      string              `json:"checkregion,omitempty"`
+	HttpRequestType  HttpRequestType     `json:"http_request_type,omitempty"`
 }
 
 type FailoverData struct {
-	FailoverType     string        `json:"check_type"`
-	DownEventHandler string        `json:"down_event_handler"`
-	UpEventHandler   string        `json:"up_event_handler"`
-	MainIP           string        `json:"main_ip"`
-	BackupIp1        string        `json:"backup_ip_1,omitempty"`
-	BackupIp2        string        `json:"backup_ip_2,omitempty"`
-	BackupIp3        string        `json:"backup_ip_3,omitempty"`
-	BackupIp4        string        `json:"backup_ip_4,omitempty"`
-	BackupIp5        string        `json:"backup_ip_5,omitempty"`
-	MonitoringRegion string        `json:"monitoring_region,omitempty"`
-	CheckSettings    CheckSettings `json:"check_settings"`
-	CheckPeriod      string        `json:"check_period,omitempty"`
-	NotificationMail string        `json:"notification_mail,omitempty"`
-	CheckRegion      string        `json:"checkregion,omitempty"`
+	FailoverType     FailoverCheckType   `json:"check_type"`
+	DownEventHandler FailoverDownHandler `json:"down_event_handler"`
+	UpEventHandler   FailoverUpHandler   `json:"up_event_handler"`
+	MainIP           string              `json:"main_ip"`
+	Backups          []string            `json:"-"` // backup_ip_1, backup_ip_2, ... on the wire
+	MonitoringRegion MonitoringRegion    `json:"monitoring_region,omitempty"`
+	CheckSettings    CheckSettings       `json:"check_settings"`
+	CheckPeriod      CheckPeriod         `json:"check_period,omitempty"`
+	NotificationMail string              `json:"notification_mail,omitempty"`
+	CheckRegion      string              `json:"checkregion,omitempty"`
 }
 
 type ActivateFailover struct {
-	Authid           int        `json:"auth-id,omitempty"`
-	Subauthid        int        `json:"sub-auth-id,omitempty"`
-	Authpassword     string     `json:"auth-password"`
-	ID               string     `json:"id"`
-	Domain           string     `json:"domain-name"`
-	RecordId         string     `json:"record-id"`
-	FailoverType     string     `json:"check_type"`
-	DownEventHandler string     `json:"down_event_handler"`
-	UpEventHandler   string     `json:"up_event_handler"`
-	MainIP           string     `json:"main_ip"`
-	BackupIp1        string     `json:"backup_ip_1,omitempty"`
-	BackupIp2        string     `json:"backup_ip_2,omitempty"`
-	BackupIp3        string     `json:"backup_ip_3,omitempty"`
-	BackupIp4        string     `json:"backup_ip_4,omitempty"`
-	BackupIp5        string     `json:"backup_ip_5,omitempty"`
-	MonitoringRegion string     `json:"monitoring_region,omitempty"`
-	Host             string     `json:"host,omitempty"`
-	Port             CustomPort `json:"port,omitempty"`
-	Path             string     `json:"path,omitempty"`
-	Content          string     `json:"content,omitempty"`
-	QueryType        string     `json:"query_type,omitempty"`
-	QueryResponse    string     `json:"query_response,omitempty"`
-	CheckPeriod      string     `json:"check_period,omitempty"`
-	NotificationMail string     `json:"notification_mail,omitempty"`
-	LatencyLimit     string     `json:"latency_limit,omitempty"`
-	Timeout          string     `json:"timeout,omitempty"`
-	CheckRegion      string     `json:"checkregion,omitempty"`
-	HttpRequestType  string     `json:"http_request_type,omitempty"`
+	Authid           int                 `json:"auth-id,omitempty"`
+	Subauthid        int                 `json:"sub-auth-id,omitempty"`
+	Subauthuser      string              `json:"sub-auth-user,omitempty"`
+	Authpassword     string              `json:"auth-password"`
+	ID               string              `json:"id"`
+	Domain           string              `json:"domain-name"`
+	RecordId         string              `json:"record-id"`
+	FailoverType     FailoverCheckType   `json:"check_type"`
+	DownEventHandler FailoverDownHandler `json:"down_event_handler"`
+	UpEventHandler   FailoverUpHandler   `json:"up_event_handler"`
+	MainIP           string              `json:"main_ip"`
+	Backups          []string            `json:"-"` // backup_ip_1, backup_ip_2, ... on the wire
+	MonitoringRegion MonitoringRegion    `json:"monitoring_region,omitempty"`
+	Host             string              `json:"host,omitempty"`
+	Port             CustomPort          `json:"port,omitempty"`
+	Path             string              `json:"path,omitempty"`
+	Content          string              `json:"content,omitempty"`
+	QueryType        string              `json:"query_type,omitempty"`
+	QueryResponse    string              `json:"query_response,omitempty"`
+	CheckPeriod      CheckPeriod         `json:"check_period,omitempty"`
+	NotificationMail string              `json:"notification_mail,omitempty"`
+	LatencyLimit     string              `json:"latency_limit,omitempty"`
+	Timeout          string              `json:"timeout,omitempty"`
+	CheckRegion      string              `json:"checkregion,omitempty"`
+	HttpRequestType  HttpRequestType     `json:"http_request_type,omitempty"`
+	Page             int                 `json:"page,omitempty"`
+	Hits             int                 `json:"rows-per-page,omitempty"`
 }
 
 type DynamicUrl struct {
@@ -493,9 +934,17 @@ type DynamicUrl struct {
 type DynamicUrlRequest struct {
 	Authid       int    `json:"auth-id,omitempty"`
 	Subauthid    int    `json:"sub-auth-id,omitempty"`
+	Subauthuser  string `json:"sub-auth-user,omitempty"`
 	Authpassword string `json:"auth-password"`
 	Domain       string `json:"domain-name"`
 	RecordId     string `json:"record-id"`
+	Page         int    `json:"page,omitempty"`
+	Hits         int    `json:"rows-per-page,omitempty"`
+}
+
+func (r DynamicUrlRequest) history() (*resty.Response, error) {
+	const path = "/dns/get-dynamic-url-history.json"
+	return apireq(path, r)
 }
 
 type DynamicUrlResponse struct {
@@ -528,9 +977,127 @@ func (cp *CustomPort) UnmarshalJSON(data []byte) error {
 	return fmt.Errorf("cannot unmarshal %s into CustomPort", string(data))
 }
 
-func (r ActivateFailover) create() (*resty.Response, error) {
+// marshalbackups adds the backup IPs of v as numbered backup_ip_N fields
+func marshalbackups(v interface{}, backups []string) ([]byte, error) {
+	b, err := json.Marshal(v)
+	if err != nil || len(backups) == 0 {
+		return b, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return nil, err
+	}
+	for i, ip := range backups {
+		if fields["backup_ip_"+strconv.Itoa(i+1)], err = json.Marshal(ip); err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(fields)
+}
+
+// unmarshalbackups collects the numbered backup_ip_N fields in order, skipping empty ones
+func unmarshalbackups(data []byte) ([]string, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	numbered := map[int]string{}
+	var nums []int
+	for k, raw := range fields {
+		n, err := strconv.Atoi(strings.TrimPrefix(k, "backup_ip_"))
+		if !strings.HasPrefix(k, "backup_ip_") || err != nil {
+			continue
+		}
+		var ip string
+		if err := json.Unmarshal(raw, &ip); err != nil || ip == "" {
+			continue
+		}
+		numbered[n] = ip
+		nums = append(nums, n)
+	}
+	sort.Ints(nums)
+	var backups []string
+	for _, n := range nums {
+		backups = append(backups, numbered[n])
+	}
+	return backups, nil
+}
+
+// unmarshalwithbackups decodes data into v, an alias of the type so its
+// UnmarshalJSON is not called again, and returns the backup_ip_N fields
+func unmarshalwithbackups(data []byte, v interface{}) ([]string, error) {
+	if err := json.Unmarshal(data, v); err != nil {
+		return nil, err
+	}
+	return unmarshalbackups(data)
+}
+
+// The failover types send and read Backups as the numbered backup_ip_N fields
+
+func (f Failover) MarshalJSON() ([]byte, error) {
+	type alias Failover
+	return marshalbackups(alias(f), f.Backups)
+}
+
+func (f *Failover) UnmarshalJSON(data []byte) (err error) {
+	type alias Failover
+	f.Backups, err = unmarshalwithbackups(data, (*alias)(f))
+	return err
+}
+
+func (f ApiFailover) MarshalJSON() ([]byte, error) {
+	type alias ApiFailover
+	return marshalbackups(alias(f), f.Backups)
+}
+
+func (f *ApiFailover) UnmarshalJSON(data []byte) (err error) {
+	type alias ApiFailover
+	f.Backups, err = unmarshalwithbackups(data, (*alias)(f))
+	return err
+}
+
+func (f FailoverData) MarshalJSON() ([]byte, error) {
+	type alias FailoverData
+	return marshalbackups(alias(f), f.Backups)
+}
+
+func (f *FailoverData) UnmarshalJSON(data []byte) (err error) {
+	type alias FailoverData
+	f.Backups, err = unmarshalwithbackups(data, (*alias)(f))
+	return err
+}
+
+func (r ActivateFailover) MarshalJSON() ([]byte, error) {
+	type alias ActivateFailover
+	return marshalbackups(alias(r), r.Backups)
+}
+
+func (r *ActivateFailover) UnmarshalJSON(data []byte) (err error) {
+	type alias ActivateFailover
+	r.Backups, err = unmarshalwithbackups(data, (*alias)(r))
+	return err
+}
+
+// flexint is an int that the API returns either as number or as string,
+// an empty string is read as 0
+type flexint int
+
+func (fi *flexint) UnmarshalJSON(data []byte) error {
+	if string(data) == `""` {
+		*fi = 0
+		return nil
+	}
+	var cp CustomPort
+	if err := cp.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	*fi = flexint(cp)
+	return nil
+}
+
+func (r ActivateFailover) create(ctx context.Context) (*resty.Response, error) {
 	const path = "/dns/failover-activate.json"
-	return apireq(path, r)
+	return apireqctx(ctx, path, r)
 }
 
 func (r ActivateFailover) update() (*resty.Response, error) {
@@ -538,12 +1105,71 @@ func (r ActivateFailover) update() (*resty.Response, error) {
 	return apireq(path, r)
 }
 
-func (r ActivateFailover) destroy() (*resty.Response, error) {
+func (r ActivateFailover) destroy(ctx context.Context) (*resty.Response, error) {
 	const path = "/dns/failover-deactivate.json"
-	return apireq(path, r)
+	return apireqctx(ctx, path, r)
 }
 
 func (r ActivateFailover) get() (*resty.Response, error) {
 	const path = "/dns/failover-settings.json"
 	return apireq(path, r)
 }
+
+func (r ActivateFailover) status() (*resty.Response, error) {
+	const path = "/dns/failover-status.json"
+	return apireq(path, r)
+}
+
+func (r ActivateFailover) history() (*resty.Response, error) {
+	const path = "/dns/failover-history.json"
+	return apireq(path, r)
+}
+
+const apitimeformat = "2006-01-02 15:04:05"
+
+// ParseAPITime reads the "2006-01-02 15:04:05" timestamps (UTC) or unix
+// timestamps used in API responses and callbacks, unparseable values give the zero time
+func ParseAPITime(v string) time.Time {
+	if t, err := time.ParseInLocation(apitimeformat, v, time.UTC); err == nil {
+		return t
+	}
+	if unix, err := strconv.ParseInt(v, 10, 64); err == nil && unix > 0 {
+		return time.Unix(unix, 0).UTC()
+	}
+	return time.Time{}
+}
+
+type failovernotification struct {
+	Authid         int    `json:"auth-id,omitempty"`
+	Subauthid      int    `json:"sub-auth-id,omitempty"`
+	Subauthuser    string `json:"sub-auth-user,omitempty"`
+	Authpassword   string `json:"auth-password"`
+	Domain         string `json:"domain-name"`
+	RecordId       string `json:"record-id"`
+	NotificationId string `json:"notification-id,omitempty"`
+	Type           string `json:"type,omitempty"`
+	Value          string `json:"value,omitempty"`
+	Page           int    `json:"page,omitempty"`
+	Hits           int    `json:"rows-per-page,omitempty"`
+}
+
+type retnotification struct {
+	ID    json.Number `json:"id"`
+	Type  string      `json:"type"`
+	Value string      `json:"value"`
+}
+
+func (n failovernotification) list() (*resty.Response, error) {
+	const path = "/dns/failover-notifications-list.json"
+	return apireq(path, n)
+}
+
+func (n failovernotification) add() (*resty.Response, error) {
+	const path = "/dns/failover-notifications-add.json"
+	return apireq(path, n)
+}
+
+func (n failovernotification) destroy() (*resty.Response, error) {
+	const path = "/dns/failover-notifications-delete.json"
+	return apireq(path, n)
+}
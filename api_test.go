@@ -0,0 +1,83 @@
+package cloudns
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// newTestAPI points apiurl at a test server for the duration of the test,
+// the handler gets the decoded request body
+func newTestAPI(t *testing.T, handler func(path string, body map[string]interface{}) interface{}) {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("Expected a json request body, got %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(handler(r.URL.Path, body))
+	}))
+	t.Cleanup(srv.Close)
+
+	orig := apiurl
+	apiurl = srv.URL
+	t.Cleanup(func() { apiurl = orig })
+}
+
+var testApiAccess = &Apiaccess{
+	Authid:       24325,
+	Authpassword: "123456",
+}
+
+func TestApireqValidatesCredentials(t *testing.T) {
+	called := false
+	newTestAPI(t, func(path string, body map[string]interface{}) interface{} {
+		called = true
+		return map[string]string{"status": "Success"}
+	})
+
+	a := Apiaccess{Authid: 1, Subauthid: 2, Authpassword: "pw"}
+	if err := a.Verify(context.Background()); err == nil {
+		t.Errorf("Expected an error for two identities")
+	}
+	if called {
+		t.Errorf("Expected no request to be sent")
+	}
+}
+
+func TestVerify(t *testing.T) {
+	newTestAPI(t, func(path string, body map[string]interface{}) interface{} {
+		if path != "/dns/login.json" {
+			t.Errorf("Unexpected path %s", path)
+		}
+		if body["auth-password"] == "123456" {
+			return map[string]string{"status": "Success", "statusDescription": "Success login."}
+		}
+		return map[string]string{"status": "Failed", "statusDescription": "Invalid authentication, incorrect auth-id or auth-password."}
+	})
+
+	if err := testApiAccess.Verify(context.Background()); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	wrong := Apiaccess{Authid: 24325, Authpassword: "wrong"}
+	if err := wrong.Verify(context.Background()); err == nil {
+		t.Errorf("Expected an error for a wrong password")
+	}
+}
+
+func TestMyIP(t *testing.T) {
+	newTestAPI(t, func(path string, body map[string]interface{}) interface{} {
+		return map[string]string{"ip": "192.0.2.10"}
+	})
+
+	ip, err := testApiAccess.MyIP(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if ip != "192.0.2.10" {
+		t.Errorf("Expected 192.0.2.10, got %s", ip)
+	}
+}
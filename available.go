@@ -0,0 +1,197 @@
+// Package cloudns available TTLs and record types
+package cloudns
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Zone types accepted by AvailableRecordTypes
+const (
+	RecordZoneDomain  = "domain"
+	RecordZoneReverse = "reverse"
+	RecordZoneParked  = "parked"
+)
+
+// ErrUnsupportedTTL is returned when a record uses a TTL the API does not accept
+var ErrUnsupportedTTL = errors.New("unsupported TTL")
+
+// ErrUnsupportedRecordType is returned when a record type is not available for the zone
+var ErrUnsupportedRecordType = errors.New("unsupported record type")
+
+// availcachetime is how long looked up TTLs, record types and zone types are used,
+// they change with the account plan, ResetAvailableCache forgets them at once
+const availcachetime = time.Hour
+
+// authkey identifies an API user, the password is only kept as hash
+type authkey struct {
+	authid      int
+	subauthid   int
+	subauthuser string
+	password    [sha256.Size]byte
+}
+
+func (a Apiaccess) authkey() authkey {
+	return authkey{
+		authid:      a.Authid,
+		subauthid:   a.Subauthid,
+		subauthuser: a.Subauthuser,
+		password:    sha256.Sum256([]byte(a.Authpassword)),
+	}
+}
+
+type rtypekey struct {
+	user  authkey
+	ztype string
+}
+
+type zonekey struct {
+	user   authkey
+	domain string
+}
+
+// availentry is a cached lookup
+type availentry[T any] struct {
+	val     T
+	expires time.Time
+}
+
+// availcache holds the available TTLs and record types and the zone types per credentials
+var availcache = struct {
+	sync.Mutex
+	ttls   map[authkey]availentry[[]int]
+	rtypes map[rtypekey]availentry[[]string]
+	zones  map[zonekey]availentry[string]
+}{
+	ttls:   map[authkey]availentry[[]int]{},
+	rtypes: map[rtypekey]availentry[[]string]{},
+	zones:  map[zonekey]availentry[string]{},
+}
+
+// ResetAvailableCache forgets the available TTLs, record types and zone types,
+// e.g. after the plan of the account changed
+func ResetAvailableCache() {
+	availcache.Lock()
+	defer availcache.Unlock()
+	clear(availcache.ttls)
+	clear(availcache.rtypes)
+	clear(availcache.zones)
+}
+
+// cachedlookup returns the cached result for key or calls fetch and caches its result,
+// errors are not cached so the next call tries again
+func cachedlookup[K comparable, T any](m map[K]availentry[T], key K, fetch func() (T, error)) (T, error) {
+	availcache.Lock()
+	e, ok := m[key]
+	availcache.Unlock()
+	if ok && time.Now().Before(e.expires) {
+		return e.val, nil
+	}
+
+	val, err := fetch()
+	if err != nil {
+		return val, err
+	}
+	availcache.Lock()
+	m[key] = availentry[T]{val: val, expires: time.Now().Add(availcachetime)}
+	availcache.Unlock()
+	return val, nil
+}
+
+// AvailableTTLs returns the TTL values in seconds the API accepts for records
+func (a Apiaccess) AvailableTTLs(ctx context.Context) ([]int, error) {
+	ttls, err := cachedlookup(availcache.ttls, a.authkey(), func() ([]int, error) {
+		var ttls []int
+		resp, err := a.availablettl(ctx)
+		if err != nil {
+			return nil, err
+		}
+		errmsg, isapierr := checkapierr(resp.Body())
+		if isapierr {
+			return nil, errors.New(errmsg)
+		}
+		if err := json.Unmarshal(resp.Body(), &ttls); err != nil {
+			return nil, fmt.Errorf("error unmarshalling response: %v", err)
+		}
+		return ttls, nil
+	})
+	return slices.Clone(ttls), err
+}
+
+// AvailableRecordTypes returns the record types available for a zone type,
+// one of RecordZoneDomain, RecordZoneReverse or RecordZoneParked
+func (a Apiaccess) AvailableRecordTypes(ctx context.Context, zoneType string) ([]string, error) {
+	key := rtypekey{user: a.authkey(), ztype: zoneType}
+	types, err := cachedlookup(availcache.rtypes, key, func() ([]string, error) {
+		var types []string
+		rt := rectypes{
+			Authid:       a.Authid,
+			Subauthid:    a.Subauthid,
+			Subauthuser:  a.Subauthuser,
+			Authpassword: a.Authpassword,
+			Ztype:        zoneType,
+		}
+		resp, err := rt.availabletype(ctx)
+		if err != nil {
+			return nil, err
+		}
+		errmsg, isapierr := checkapierr(resp.Body())
+		if isapierr {
+			return nil, errors.New(errmsg)
+		}
+		if err := json.Unmarshal(resp.Body(), &types); err != nil {
+			return nil, fmt.Errorf("error unmarshalling response: %v", err)
+		}
+		return types, nil
+	})
+	return slices.Clone(types), err
+}
+
+// recordzonetype returns the zone type of the domain for AvailableRecordTypes, read from Zone.Info
+func (a Apiaccess) recordzonetype(ctx context.Context, domain string) (string, error) {
+	key := zonekey{user: a.authkey(), domain: strings.ToLower(strings.TrimSuffix(domain, "."))}
+	return cachedlookup(availcache.zones, key, func() (string, error) {
+		info, err := Zone{Domain: domain}.Info(ctx, &a)
+		if err != nil {
+			return "", err
+		}
+		switch {
+		case info.Kind == RecordZoneParked:
+			return RecordZoneParked, nil
+		case strings.Contains(info.Kind, "arpa"):
+			return RecordZoneReverse, nil
+		}
+		return RecordZoneDomain, nil
+	})
+}
+
+// Validate checks the TTL and the record type of a record against the values the API accepts
+func (r Record) Validate(ctx context.Context, a *Apiaccess) error {
+	ttls, err := a.AvailableTTLs(ctx)
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(ttls, r.TTL) {
+		return fmt.Errorf("%w %d for %s %s", ErrUnsupportedTTL, r.TTL, r.Rtype, r.Host)
+	}
+
+	ztype, err := a.recordzonetype(ctx, r.Domain)
+	if err != nil {
+		return err
+	}
+	types, err := a.AvailableRecordTypes(ctx, ztype)
+	if err != nil {
+		return err
+	}
+	if !slices.Contains(types, r.Rtype) {
+		return fmt.Errorf("%w %s in %s zone %s", ErrUnsupportedRecordType, r.Rtype, ztype, r.Domain)
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+package cloudns
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func newAvailableTestAPI(t *testing.T) map[string]int {
+	t.Helper()
+	calls := map[string]int{}
+	newTestAPI(t, func(path string, body map[string]interface{}) interface{} {
+		calls[path]++
+		switch path {
+		case "/dns/get-available-ttl.json":
+			return []int{60, 300, 900, 1800, 3600}
+		case "/dns/get-available-record-types.json":
+			switch body["zone-type"] {
+			case RecordZoneReverse:
+				return []string{"PTR", "CNAME", "NS", "TXT"}
+			case RecordZoneParked:
+				return []string{"A", "TXT"}
+			}
+			return []string{"A", "AAAA", "MX", "CNAME", "TXT", "NS", "SRV"}
+		case "/dns/get-zone-info.json":
+			return zoneInfoResponse(body)
+		case "/dns/add-record.json":
+			return map[string]interface{}{"status": "Success", "data": map[string]int{"id": 1234}}
+		}
+		t.Errorf("Unexpected path %s", path)
+		return nil
+	})
+	resetAvailCache(t)
+	return calls
+}
+
+// resetAvailCache clears the available TTL, record type and zone type cache after the test
+func resetAvailCache(t *testing.T) {
+	t.Cleanup(ResetAvailableCache)
+}
+
+// zoneInfoResponse answers get-zone-info for the domain in the request body
+func zoneInfoResponse(body map[string]interface{}) interface{} {
+	domain, _ := body["domain-name"].(string)
+	kind := "domain"
+	switch {
+	case strings.HasSuffix(domain, ".in-addr.arpa"):
+		kind = "ipv4 arpa"
+	case strings.HasPrefix(domain, "parked"):
+		kind = "parked"
+	}
+	return map[string]string{"name": domain, "type": "master", "zone": kind, "status": "1"}
+}
+
+func TestAvailableTTLsCached(t *testing.T) {
+	calls := newAvailableTestAPI(t)
+
+	for range 3 {
+		ttls, err := testApiAccess.AvailableTTLs(context.Background())
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if !slices.Equal(ttls, []int{60, 300, 900, 1800, 3600}) {
+			t.Errorf("Unexpected TTLs %v", ttls)
+		}
+	}
+	if calls["/dns/get-available-ttl.json"] != 1 {
+		t.Errorf("Expected one API call, got %d", calls["/dns/get-available-ttl.json"])
+	}
+}
+
+func TestRecordCreateValidation(t *testing.T) {
+	calls := newAvailableTestAPI(t)
+
+	r := Record{Domain: "testzone.bg", Host: "www", Rtype: "A", Record: "192.0.2.1", TTL: 3600}
+	rc, err := r.Create(testApiAccess)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if rc.ID != "1234" {
+		t.Errorf("Expected record id 1234, got %q", rc.ID)
+	}
+
+	r.TTL = 42
+	if _, err := r.Create(testApiAccess); !errors.Is(err, ErrUnsupportedTTL) {
+		t.Errorf("Expected ErrUnsupportedTTL, got %v", err)
+	}
+
+	r.TTL = 3600
+	r.Rtype = "PTR"
+	if _, err := r.Create(testApiAccess); !errors.Is(err, ErrUnsupportedRecordType) {
+		t.Errorf("Expected ErrUnsupportedRecordType, got %v", err)
+	}
+
+	r.Domain = "2.0.192.in-addr.arpa"
+	if _, err := r.Create(testApiAccess); err != nil {
+		t.Errorf("Expected PTR to be valid in a reverse zone, got %v", err)
+	}
+
+	r.Domain = "parked.bg"
+	r.Rtype = "MX"
+	if _, err := r.Create(testApiAccess); !errors.Is(err, ErrUnsupportedRecordType) {
+		t.Errorf("Expected ErrUnsupportedRecordType for MX in a parked zone, got %v", err)
+	}
+
+	if calls["/dns/add-record.json"] != 2 {
+		t.Errorf("Expected two records to be sent, got %d", calls["/dns/add-record.json"])
+	}
+	if calls["/dns/get-zone-info.json"] != 3 {
+		t.Errorf("Expected one zone lookup per zone, got %d", calls["/dns/get-zone-info.json"])
+	}
+}
+
+func TestAvailableCacheKey(t *testing.T) {
+	calls := newAvailableTestAPI(t)
+
+	a := Apiaccess{Authid: testApiAccess.Authid, Authpassword: "other-password"}
+	for _, ac := range []Apiaccess{*testApiAccess, *testApiAccess, a} {
+		if _, err := ac.AvailableTTLs(context.Background()); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+	if calls["/dns/get-available-ttl.json"] != 2 {
+		t.Errorf("Expected one API call per credentials, got %d", calls["/dns/get-available-ttl.json"])
+	}
+	for k := range availcache.ttls {
+		if k.subauthuser != "" || k.password == ([32]byte{}) {
+			t.Errorf("Unexpected cache key %+v", k)
+		}
+	}
+
+	ResetAvailableCache()
+	if _, err := testApiAccess.AvailableTTLs(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if calls["/dns/get-available-ttl.json"] != 3 {
+		t.Errorf("Expected a new API call after ResetAvailableCache, got %d", calls["/dns/get-available-ttl.json"])
+	}
+}
+
+func TestAvailableLookupFailureNotCached(t *testing.T) {
+	resetAvailCache(t)
+	calls := 0
+	newTestAPI(t, func(path string, body map[string]interface{}) interface{} {
+		calls++
+		if calls == 1 {
+			return map[string]string{"status": "Failed", "statusDescription": "Invalid authentication, incorrect auth-id or auth-password."}
+		}
+		return []int{60, 3600}
+	})
+
+	if _, err := testApiAccess.AvailableTTLs(context.Background()); err == nil {
+		t.Errorf("Expected an error")
+	}
+	for range 2 {
+		if ttls, err := testApiAccess.AvailableTTLs(context.Background()); err != nil || len(ttls) != 2 {
+			t.Errorf("Unexpected TTLs %v %v", ttls, err)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("Expected the failure to be retried and the result cached, got %d API calls", calls)
+	}
+}
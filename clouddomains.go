@@ -0,0 +1,93 @@
+// Package cloudns cloud domains
+package cloudns
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/tidwall/gjson"
+)
+
+// CloudDomain is a zone that shares the records of the master zone of its cloud,
+// changes to any zone of the cloud apply to all of them
+type CloudDomain struct {
+	Domain string `json:"name"`
+	Master bool   `json:"master"`
+}
+
+func newCloudDomain(domain string, cloud string, a *Apiaccess) clouddomain {
+	return clouddomain{
+		Authid:       a.Authid,
+		Subauthid:    a.Subauthid,
+		Subauthuser:  a.Subauthuser,
+		Authpassword: a.Authpassword,
+		Domain:       domain,
+		CloudDomain:  cloud,
+	}
+}
+
+// CloudDomains returns all zones in the cloud of the zone, including the master
+func (z Zone) CloudDomains(a *Apiaccess) ([]CloudDomain, error) {
+	var rc []CloudDomain
+	resp, err := newCloudDomain(z.Domain, "", a).list()
+	if err != nil {
+		return rc, err
+	}
+	errmsg, isapierr := checkapierr(resp.Body())
+	if isapierr {
+		return rc, errors.New(errmsg)
+	}
+	res := gjson.ParseBytes(resp.Body())
+	if !res.IsArray() && !res.IsObject() {
+		return rc, fmt.Errorf("unexpected response %q", resp.Body())
+	}
+	res.ForEach(func(key, value gjson.Result) bool {
+		rc = append(rc, CloudDomain{
+			Domain: value.Get("name").String(),
+			Master: value.Get("master").Bool(),
+		})
+		return true
+	})
+	return rc, nil
+}
+
+// AddCloudDomain adds a new zone for domain to the cloud of the zone
+func (z Zone) AddCloudDomain(a *Apiaccess, domain string) (CloudDomain, error) {
+	c := CloudDomain{Domain: domain}
+	if domain == "" {
+		return c, errors.New("cloud domain needs a name")
+	}
+	resp, err := newCloudDomain(z.Domain, domain, a).add()
+	if err == nil {
+		errmsg, isapierr := checkapierr(resp.Body())
+		if isapierr {
+			return c, errors.New(errmsg)
+		}
+	}
+	return c, err
+}
+
+// Delete the cloud domain, the master can only be deleted when it is the last domain of the cloud
+func (c CloudDomain) Delete(a *Apiaccess) (CloudDomain, error) {
+	resp, err := newCloudDomain(c.Domain, "", a).destroy()
+	if err == nil {
+		errmsg, isapierr := checkapierr(resp.Body())
+		if isapierr {
+			return c, errors.New(errmsg)
+		}
+	}
+	return c, err
+}
+
+// SetMaster makes the cloud domain the master zone of its cloud
+func (c CloudDomain) SetMaster(a *Apiaccess) (CloudDomain, error) {
+	resp, err := newCloudDomain(c.Domain, "", a).setmaster()
+	if err == nil {
+		errmsg, isapierr := checkapierr(resp.Body())
+		if isapierr {
+			return c, errors.New(errmsg)
+		}
+		c.Master = true
+	}
+	return c, err
+}
@@ -0,0 +1,43 @@
+package cloudns
+
+import (
+	"testing"
+)
+
+func TestCloudDomains(t *testing.T) {
+	var sent map[string]interface{}
+	newTestAPI(t, func(path string, body map[string]interface{}) interface{} {
+		sent = body
+		switch path {
+		case "/dns/list-cloud-domains.json":
+			return map[string]interface{}{
+				"testzone.bg":  map[string]interface{}{"name": "testzone.bg", "master": true},
+				"testzone2.bg": map[string]interface{}{"name": "testzone2.bg", "master": "0"},
+			}
+		case "/dns/add-cloud-domain.json", "/dns/delete-cloud-domain.json", "/dns/set-master-cloud-domain.json":
+			return map[string]string{"status": "Success"}
+		}
+		t.Errorf("Unexpected path %s", path)
+		return nil
+	})
+
+	z := Zone{Domain: "testzone.bg"}
+	domains, err := z.CloudDomains(testApiAccess)
+	if err != nil || len(domains) != 2 || domains[0] != (CloudDomain{Domain: "testzone.bg", Master: true}) || domains[1] != (CloudDomain{Domain: "testzone2.bg"}) {
+		t.Errorf("Unexpected cloud domains %+v %v", domains, err)
+	}
+
+	c, err := z.AddCloudDomain(testApiAccess, "testzone3.bg")
+	if err != nil || sent["domain-name"] != "testzone.bg" || sent["cloud-domain-name"] != "testzone3.bg" {
+		t.Errorf("Unexpected add %+v %v %v", c, err, sent)
+	}
+
+	c, err = c.SetMaster(testApiAccess)
+	if err != nil || !c.Master || sent["domain-name"] != "testzone3.bg" {
+		t.Errorf("Unexpected set master %+v %v %v", c, err, sent)
+	}
+
+	if _, err = domains[1].Delete(testApiAccess); err != nil || sent["domain-name"] != "testzone2.bg" {
+		t.Errorf("Unexpected delete %v %v", err, sent)
+	}
+}
@@ -2,6 +2,7 @@
 package cloudns
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -14,9 +15,32 @@ import (
 type Apiaccess struct {
 	Authid       int    `json:"auth-id,omitempty"`
 	Subauthid    int    `json:"sub-auth-id,omitempty"`
+	Subauthuser  string `json:"sub-auth-user,omitempty"`
 	Authpassword string `json:"auth-password"`
 }
 
+// Validate checks that exactly one of Authid, Subauthid or Subauthuser is set
+// together with a password
+func (a Apiaccess) Validate() error {
+	ids := 0
+	if a.Authid != 0 {
+		ids++
+	}
+	if a.Subauthid != 0 {
+		ids++
+	}
+	if a.Subauthuser != "" {
+		ids++
+	}
+	if ids != 1 {
+		return errors.New("exactly one of auth-id, sub-auth-id or sub-auth-user must be set")
+	}
+	if a.Authpassword == "" {
+		return errors.New("auth-password is required")
+	}
+	return nil
+}
+
 // Ns is the external representation of a nameserver
 type Ns struct {
 	Id            string `json:"id,omitempty"`
@@ -36,13 +60,21 @@ type Zone struct {
 	Ztype  string   `json:"zone-type"`
 	Ns     []string `json:"ns,omitempty"`
 	Master string   `json:"master-ip,omitempty"`
+	// Group is the name of the zone group, it is set by Read, Listzones and
+	// MoveToGroup and puts a new zone into the group on Create, see ZoneGroup
+	Group string `json:"group,omitempty"`
+	// Active is false for a disabled zone, it is set by Read and Listzones
+	Active bool `json:"active"`
+	// TemplateID applies the DNS template to a new zone on Create, see Template
+	TemplateID int `json:"template-id,omitempty"`
 }
 
-// Listns returns all ns servers available
+// List returns all ns servers available with their addresses and location
 func (n Ns) List(a Apiaccess) ([]Ns, error) {
 	nsl := nslist{
 		Authid:       a.Authid,
 		Subauthid:    a.Subauthid,
+		Subauthuser:  a.Subauthuser,
 		Authpassword: a.Authpassword,
 		DetailedInfo: 1,
 	}
@@ -59,8 +91,14 @@ func (n Ns) List(a Apiaccess) ([]Ns, error) {
 	err = json.Unmarshal(resp.Body(), &intrn)
 	for _, ns := range intrn {
 		tmpns := Ns{
-			Type: ns.Type,
-			Name: ns.Name,
+			Id:            ns.ID,
+			Type:          ns.Type,
+			Name:          ns.Name,
+			Ip4:           ns.Ip4,
+			Ip6:           ns.Ip6,
+			Location:      ns.Location,
+			LocationCc:    ns.LocationCc,
+			DdosProtected: int(ns.DdosProtected),
 		}
 		rn = append(rn, tmpns)
 	}
@@ -69,13 +107,17 @@ func (n Ns) List(a Apiaccess) ([]Ns, error) {
 
 // Listzones returns all zones (max: 100)
 func (a Apiaccess) Listzones() ([]Zone, error) {
-	zls := zonelist{
+	return listzones(zonelist{
 		Authid:       a.Authid,
 		Subauthid:    a.Subauthid,
+		Subauthuser:  a.Subauthuser,
 		Authpassword: a.Authpassword,
 		Page:         1,
 		Hits:         100,
-	}
+	})
+}
+
+func listzones(zls zonelist) ([]Zone, error) {
 	resp, err := zls.lszone()
 	var rz []Zone
 	if err == nil {
@@ -89,6 +131,8 @@ func (a Apiaccess) Listzones() ([]Zone, error) {
 			tmpzn := Zone{
 				Domain: zn.Domain,
 				Ztype:  zn.Ztype,
+				Group:  zn.Group,
+				Active: zn.Status == 1,
 			}
 			rz = append(rz, tmpzn)
 		}
@@ -99,14 +143,19 @@ func (a Apiaccess) Listzones() ([]Zone, error) {
 
 // List returns all records from a zone
 func (z Zone) List(a *Apiaccess) ([]Record, error) {
+	return z.list(context.Background(), a)
+}
+
+func (z Zone) list(ctx context.Context, a *Apiaccess) ([]Record, error) {
 	var ra []Record
 	rls := reclist{
 		Authid:       a.Authid,
 		Subauthid:    a.Subauthid,
+		Subauthuser:  a.Subauthuser,
 		Authpassword: a.Authpassword,
 		Domain:       z.Domain,
 	}
-	resp, err := rls.lsrec()
+	resp, err := rls.lsrec(ctx)
 	if err == nil {
 		errmsg, isapierr := checkapierr(resp.Body())
 		if isapierr {
@@ -115,129 +164,21 @@ func (z Zone) List(a *Apiaccess) ([]Record, error) {
 		var ratmp map[string]retrec
 		err2 := json.Unmarshal(resp.Body(), &ratmp)
 		for _, rec := range ratmp {
-
-			tmpttl, _ := strconv.Atoi(rec.TTL)
-			tmppriority, _ := strconv.Atoi(rec.Priority)
-			tmpframe := rec.Frame
-			tmpframetitle := rec.FrameTitle
-			tmpframekeywords := rec.FrameKeywords
-			tmpframedescription := rec.FrameDescription
-			tmpmobilemeta := rec.MobileMeta
-			tmpsavepath := rec.SavePath
-			tmpredirecttype := rec.RedirectType
-			tmpweight, _ := strconv.Atoi(rec.Weight)
-			tmpport, _ := strconv.Atoi(rec.Port)
-			tmpmail := rec.Mail
-			tmptxt := rec.Txt
-			tmpalgorithm, _ := strconv.Atoi(rec.Algorithm)
-			tmpfptype := rec.Fptype
-			tmpflag := rec.Flag
-			tmporder := rec.Order
-			tmppref := rec.Pref
-			tmpparams := rec.Params
-			tmpregexp := rec.Regexp
-			tmpreplace := rec.Replace
-			tmpcaaflag := rec.CaaFlag
-			tmpcaatype := rec.CaaType
-			tmpcaavalue := rec.CaaValue
-			tmptlsausage := rec.TlsaUsage
-			tmptlsaselector := rec.TlsaSelector
-			tmptlsamatchingtype := rec.TlsaMatchingType
-			tmpkeytag := rec.KeyTag
-			tmpdigesttype := rec.DigestType
-			tmpcerttype := rec.CertType
-			tmpcertkeytag := rec.CertKeyTag
-			tmpcertalgorithm := rec.CertAlgorithm
-			tmpcpu := rec.CPU
-			tmpos := rec.OS
-			tmplatdeg := rec.LatDeg
-			tmplatmin := rec.LatMin
-			tmplatsec := rec.LatSec
-			tmplatdir := rec.LatDir
-			tmplongdeg := rec.LongDeg
-			tmplongmin := rec.LongMin
-			tmplongsec := rec.LongSec
-			tmplongdir := rec.LongDir
-			tmpaltitude := rec.Altitude
-			tmpsize := rec.Size
-			tmphprecision := rec.HPrecision
-			tmpvprecision := rec.VPrecision
-			tmpsmimeausage := rec.SmimeaUsage
-			tmpsmimeaselector := rec.SmimeaSelector
-			tmpsmimeamatchingtype := rec.SmimeaMatchingType
-			tmpgeodnscode := rec.GeodnsCode
-			tmpgeodnslocation := rec.GeodnsLocation
-
-			rectmp := Record{
-				Domain:             z.Domain,
-				ID:                 rec.ID,
-				Rtype:              rec.Rtype,
-				Host:               rec.Host,
-				TTL:                tmpttl,
-				Record:             rec.Record,
-				Priority:           tmppriority,
-				Frame:              tmpframe,
-				FrameTitle:         tmpframetitle,
-				FrameKeywords:      tmpframekeywords,
-				FrameDescription:   tmpframedescription,
-				MobileMeta:         tmpmobilemeta,
-				SavePath:           tmpsavepath,
-				RedirectType:       tmpredirecttype,
-				Weight:             tmpweight,
-				Port:               tmpport,
-				Mail:               tmpmail,
-				Txt:                tmptxt,
-				Algorithm:          tmpalgorithm,
-				Fptype:             tmpfptype,
-				Flag:               tmpflag,
-				Order:              tmporder,
-				Pref:               tmppref,
-				Params:             tmpparams,
-				Regexp:             tmpregexp,
-				Replace:            tmpreplace,
-				CaaFlag:            tmpcaaflag,
-				CaaType:            tmpcaatype,
-				CaaValue:           tmpcaavalue,
-				TlsaUsage:          tmptlsausage,
-				TlsaSelector:       tmptlsaselector,
-				TlsaMatchingType:   tmptlsamatchingtype,
-				KeyTag:             tmpkeytag,
-				DigestType:         tmpdigesttype,
-				CertType:           tmpcerttype,
-				CertKeyTag:         tmpcertkeytag,
-				CertAlgorithm:      tmpcertalgorithm,
-				CPU:                tmpcpu,
-				OS:                 tmpos,
-				LatDeg:             tmplatdeg,
-				LatMin:             tmplatmin,
-				LatSec:             tmplatsec,
-				LatDir:             tmplatdir,
-				LongDeg:            tmplongdeg,
-				LongMin:            tmplongmin,
-				LongSec:            tmplongsec,
-				LongDir:            tmplongdir,
-				Altitude:           tmpaltitude,
-				Size:               tmpsize,
-				HPrecision:         tmphprecision,
-				VPrecision:         tmpvprecision,
-				SmimeaUsage:        tmpsmimeausage,
-				SmimeaSelector:     tmpsmimeaselector,
-				SmimeaMatchingType: tmpsmimeamatchingtype,
-				GeodnsLocation:     tmpgeodnslocation,
-				GeodnsCode:         tmpgeodnscode,
-			}
-			ra = append(ra, rectmp)
+			ra = append(ra, rec.record(z.Domain))
 		}
 		return ra, err2
 	}
 	return ra, err
 }
 
-// Create a new zone
+// Create a new zone, if it is registered but TemplateID can not be applied or
+// it can not be moved to Group the zone is returned with an ErrZoneSetup error,
+// creating it again would fail
 func (z Zone) Create(a *Apiaccess) (Zone, error) {
 	cr := createzone{
 		Authid:       a.Authid,
 		Subauthid:    a.Subauthid,
+		Subauthuser:  a.Subauthuser,
 		Authpassword: a.Authpassword,
 		Domain:       z.Domain,
 		Ztype:        z.Ztype,
@@ -250,6 +191,24 @@ func (z Zone) Create(a *Apiaccess) (Zone, error) {
 		if isapierr {
 			return z, errors.New(errmsg)
 		}
+		// the zone exists now, a failed step must not skip the next one
+		var setup []error
+		if z.TemplateID != 0 {
+			if _, err := z.ApplyTemplate(a, z.TemplateID); err != nil {
+				setup = append(setup, fmt.Errorf("applying template %d to %s: %w", z.TemplateID, z.Domain, err))
+			}
+		}
+		if z.Group != "" {
+			group := z.Group
+			z.Group = ""
+			if z, err = z.MoveToGroup(a, ZoneGroup{Name: group}); err != nil {
+				setup = append(setup, fmt.Errorf("moving %s to group %q: %w", z.Domain, group, err))
+			}
+		}
+		if len(setup) > 0 {
+			return z, fmt.Errorf("%w: %w", ErrZoneSetup, errors.Join(setup...))
+		}
+		return z, nil
 	}
 	return z, err
 }
@@ -259,6 +218,7 @@ func (z Zone) Read(a *Apiaccess) (Zone, error) {
 	cr := createzone{
 		Authid:       a.Authid,
 		Subauthid:    a.Subauthid,
+		Subauthuser:  a.Subauthuser,
 		Authpassword: a.Authpassword,
 		Domain:       z.Domain,
 		Ztype:        z.Ztype,
@@ -310,10 +270,10 @@ func (z Zone) Read(a *Apiaccess) (Zone, error) {
 		Domain: matchedZone.Domain,
 		Ztype:  matchedZone.Ztype,
 		Ns:     nsList,
+		Group:  matchedZone.Group,
+		Active: matchedZone.Status == 1,
 	}
 
-	fmt.Printf("Parsed zone: Domain: %s, Type: %s\n", rz.Domain, rz.Ztype)
-
 	return rz, nil
 }
 
@@ -323,11 +283,44 @@ func (z Zone) Update(a *Apiaccess) (Zone, error) {
 	return z, err
 }
 
+// Enable a disabled zone, the nameservers answer for it again
+func (z Zone) Enable(a *Apiaccess) (Zone, error) {
+	return z.setstatus(a, true)
+}
+
+// Disable a zone without deleting it, the nameservers stop answering for it
+func (z Zone) Disable(a *Apiaccess) (Zone, error) {
+	return z.setstatus(a, false)
+}
+
+func (z Zone) setstatus(a *Apiaccess, active bool) (Zone, error) {
+	zs := zonestatus{
+		Authid:       a.Authid,
+		Subauthid:    a.Subauthid,
+		Subauthuser:  a.Subauthuser,
+		Authpassword: a.Authpassword,
+		Domain:       z.Domain,
+	}
+	if active {
+		zs.Status = 1
+	}
+	resp, err := zs.change()
+	if err == nil {
+		errmsg, isapierr := checkapierr(resp.Body())
+		if isapierr {
+			return z, errors.New(errmsg)
+		}
+		z.Active = active
+	}
+	return z, err
+}
+
 // Destroy a zone
 func (z Zone) Destroy(a *Apiaccess) (Zone, error) {
 	cr := createzone{
 		Authid:       a.Authid,
 		Subauthid:    a.Subauthid,
+		Subauthuser:  a.Subauthuser,
 		Authpassword: a.Authpassword,
 		Domain:       z.Domain,
 		Ztype:        z.Ztype,
@@ -406,11 +399,12 @@ type Record struct {
 	OS                 string  `json:"os,omitempty"`
 }
 
-// Create a new record
-func (r Record) Create(a *Apiaccess) (Record, error) {
+// newCreaterec builds the request for a record, with the fields the record type needs
+func newCreaterec(r Record, a *Apiaccess) createrec {
 	inr := createrec{
 		Authid:       a.Authid,
 		Subauthid:    a.Subauthid,
+		Subauthuser:  a.Subauthuser,
 		Authpassword: a.Authpassword,
 		Domain:       r.Domain,
 		Host:         r.Host,
@@ -418,7 +412,6 @@ func (r Record) Create(a *Apiaccess) (Record, error) {
 		TTL:          r.TTL,
 		Record:       r.Record,
 	}
-
 	if r.Rtype == "MX" {
 		inr.Priority = &r.Priority
 	} else if r.Rtype == "WR" {
@@ -426,8 +419,8 @@ func (r Record) Create(a *Apiaccess) (Record, error) {
 		inr.FrameTitle = r.FrameTitle
 		inr.FrameKeywords = r.FrameKeywords
 		inr.FrameDescription = r.FrameDescription
-		inr.MobileMeta = r.MobileMeta
-		inr.SavePath = r.SavePath
+		inr.MobileMeta = &r.MobileMeta
+		inr.SavePath = &r.SavePath
 		inr.RedirectType = r.RedirectType
 	} else if r.Rtype == "SRV" {
 		inr.Priority = &r.Priority
@@ -489,165 +482,16 @@ func (r Record) Create(a *Apiaccess) (Record, error) {
 	if r.GeodnsCode != "" {
 		inr.GeodnsCode = r.GeodnsCode
 	}
-
-	resp, err := inr.create()
-	if err == nil {
-		errmsg, isapierr := checkapierr(resp.Body())
-		if isapierr {
-			return r, errors.New(errmsg)
-		}
-		newid := gjson.GetBytes(resp.Body(), "data.id")
-		r.ID = newid.String()
-	}
-	return r, err
-}
-
-// Read a record
-func (r Record) Read(a *Apiaccess) (Record, error) {
-	lsr := reclist{
-		Authid:       a.Authid,
-		Subauthid:    a.Subauthid,
-		Authpassword: a.Authpassword,
-		Domain:       r.Domain,
-		Host:         r.Host,
-		Rtype:        r.Rtype,
-	}
-	resp, err := lsr.lsrec()
-	if err == nil {
-		errmsg, isapierr := checkapierr(resp.Body())
-		if isapierr {
-			return r, errors.New(errmsg)
-		}
-		var ratmp map[string]retrec
-		err2 := json.Unmarshal(resp.Body(), &ratmp)
-		for _, rec := range ratmp {
-			tmpttl, _ := strconv.Atoi(rec.TTL)
-			tmppriority, _ := strconv.Atoi(rec.Priority)
-			tmpframe := rec.Frame
-			tmpframetitle := rec.FrameTitle
-			tmpframekeywords := rec.FrameKeywords
-			tmpframedescription := rec.FrameDescription
-			tmpmobilemeta := rec.MobileMeta
-			tmpsavepath := rec.SavePath
-			tmpredirecttype := rec.RedirectType
-			tmpweight, _ := strconv.Atoi(rec.Weight)
-			tmpport, _ := strconv.Atoi(rec.Port)
-			tmptxt := rec.Txt
-			tmpmail := rec.Mail
-			tmpalgorithm, _ := strconv.Atoi(rec.Algorithm)
-			tmpfptype := rec.Fptype
-			tmpflag := rec.Flag
-			tmporder := rec.Order
-			tmppref := rec.Pref
-			tmpparams := rec.Params
-			tmpregexp := rec.Regexp
-			tmpreplace := rec.Replace
-			tmpcaaflag := rec.CaaFlag
-			tmpcaatype := rec.CaaType
-			tmpcaavalue := rec.CaaValue
-			tmptlsausage := rec.TlsaUsage
-			tmptlsaselector := rec.TlsaSelector
-			tmptlsamatchingtype := rec.TlsaMatchingType
-			tmpkeytag := rec.KeyTag
-			tmpdigesttype := rec.DigestType
-			tmpcerttype := rec.CertType
-			tmpcertkeytag := rec.CertKeyTag
-			tmpcertalgorithm := rec.CertAlgorithm
-			tmpcpu := rec.CPU
-			tmpos := rec.OS
-			tmplatdeg := rec.LatDeg
-			tmplatmin := rec.LatMin
-			tmplatsec := rec.LatSec
-			tmplatdir := rec.LatDir
-			tmplongdeg := rec.LongDeg
-			tmplongmin := rec.LongMin
-			tmplongsec := rec.LongSec
-			tmplongdir := rec.LongDir
-			tmpaltitude := rec.Altitude
-			tmpsize := rec.Size
-			tmphprecision := rec.HPrecision
-			tmpvprecision := rec.VPrecision
-			tmpsmimeausage := rec.SmimeaUsage
-			tmpsmimeaselector := rec.SmimeaSelector
-			tmpsmimeamatchingtype := rec.SmimeaMatchingType
-			tmpgeodnscode := rec.GeodnsCode
-			tmpgeodnslocation := rec.GeodnsLocation
-
-			rectmp := Record{
-				Domain:             r.Domain,
-				ID:                 rec.ID,
-				Rtype:              rec.Rtype,
-				Host:               rec.Host,
-				TTL:                tmpttl,
-				Record:             rec.Record,
-				Priority:           tmppriority,
-				Frame:              tmpframe,
-				FrameTitle:         tmpframetitle,
-				FrameKeywords:      tmpframekeywords,
-				FrameDescription:   tmpframedescription,
-				MobileMeta:         tmpmobilemeta,
-				SavePath:           tmpsavepath,
-				RedirectType:       tmpredirecttype,
-				Weight:             tmpweight,
-				Port:               tmpport,
-				Mail:               tmpmail,
-				Txt:                tmptxt,
-				Algorithm:          tmpalgorithm,
-				Fptype:             tmpfptype,
-				Flag:               tmpflag,
-				Order:              tmporder,
-				Pref:               tmppref,
-				Params:             tmpparams,
-				Regexp:             tmpregexp,
-				Replace:            tmpreplace,
-				CaaFlag:            tmpcaaflag,
-				CaaType:            tmpcaatype,
-				CaaValue:           tmpcaavalue,
-				TlsaUsage:          tmptlsausage,
-				TlsaSelector:       tmptlsaselector,
-				TlsaMatchingType:   tmptlsamatchingtype,
-				KeyTag:             tmpkeytag,
-				DigestType:         tmpdigesttype,
-				CertType:           tmpcerttype,
-				CertKeyTag:         tmpcertkeytag,
-				CertAlgorithm:      tmpcertalgorithm,
-				CPU:                tmpcpu,
-				OS:                 tmpos,
-				LatDeg:             tmplatdeg,
-				LatMin:             tmplatmin,
-				LatSec:             tmplatsec,
-				LatDir:             tmplatdir,
-				LongDeg:            tmplongdeg,
-				LongMin:            tmplongmin,
-				LongSec:            tmplongsec,
-				LongDir:            tmplongdir,
-				Altitude:           tmpaltitude,
-				Size:               tmpsize,
-				HPrecision:         tmphprecision,
-				VPrecision:         tmpvprecision,
-				SmimeaUsage:        tmpsmimeausage,
-				SmimeaSelector:     tmpsmimeaselector,
-				SmimeaMatchingType: tmpsmimeamatchingtype,
-				GeodnsLocation:     tmpgeodnslocation,
-				GeodnsCode:         tmpgeodnscode,
-			}
-			if r.ID != "" && r.ID == rectmp.ID {
-				return rectmp, err2
-			}
-
-			return rectmp, err2
-		}
-		return r, err2
-	}
-	return r, err
+	return inr
 }
 
-// Update a record
-func (r Record) Update(a *Apiaccess) (Record, error) {
+// newUpdaterec builds the request for a record, with the fields the record type needs
+func newUpdaterec(r Record, a *Apiaccess) updaterec {
 	tmpid, _ := strconv.Atoi(r.ID)
 	inr := updaterec{
 		Authid:       a.Authid,
 		Subauthid:    a.Subauthid,
+		Subauthuser:  a.Subauthuser,
 		Authpassword: a.Authpassword,
 		Rid:          tmpid,
 		Domain:       r.Domain,
@@ -662,8 +506,8 @@ func (r Record) Update(a *Apiaccess) (Record, error) {
 		inr.FrameTitle = r.FrameTitle
 		inr.FrameKeywords = r.FrameKeywords
 		inr.FrameDescription = r.FrameDescription
-		inr.MobileMeta = r.MobileMeta
-		inr.SavePath = r.SavePath
+		inr.MobileMeta = &r.MobileMeta
+		inr.SavePath = &r.SavePath
 		inr.RedirectType = r.RedirectType
 	} else if r.Rtype == "SRV" {
 		inr.Priority = &r.Priority
@@ -725,8 +569,188 @@ func (r Record) Update(a *Apiaccess) (Record, error) {
 	if r.GeodnsCode != "" {
 		inr.GeodnsCode = r.GeodnsCode
 	}
+	return inr
+}
+
+// record converts a record of a records.json response
+func (rec retrec) record(domain string) Record {
+	tmpttl, _ := strconv.Atoi(rec.TTL)
+	tmppriority, _ := strconv.Atoi(rec.Priority)
+	tmpframe := rec.Frame
+	tmpframetitle := rec.FrameTitle
+	tmpframekeywords := rec.FrameKeywords
+	tmpframedescription := rec.FrameDescription
+	tmpmobilemeta := int(rec.MobileMeta)
+	tmpsavepath := int(rec.SavePath)
+	tmpredirecttype := int(rec.RedirectType)
+	tmpweight, _ := strconv.Atoi(rec.Weight)
+	tmpport, _ := strconv.Atoi(rec.Port)
+	tmpmail := rec.Mail
+	tmptxt := rec.Txt
+	tmpalgorithm, _ := strconv.Atoi(rec.Algorithm)
+	tmpfptype := rec.Fptype
+	tmpflag := rec.Flag
+	tmporder := rec.Order
+	tmppref := rec.Pref
+	tmpparams := rec.Params
+	tmpregexp := rec.Regexp
+	tmpreplace := rec.Replace
+	tmpcaaflag := rec.CaaFlag
+	tmpcaatype := rec.CaaType
+	tmpcaavalue := rec.CaaValue
+	tmptlsausage := rec.TlsaUsage
+	tmptlsaselector := rec.TlsaSelector
+	tmptlsamatchingtype := rec.TlsaMatchingType
+	tmpkeytag := rec.KeyTag
+	tmpdigesttype := rec.DigestType
+	tmpcerttype := rec.CertType
+	tmpcertkeytag := rec.CertKeyTag
+	tmpcertalgorithm := rec.CertAlgorithm
+	tmpcpu := rec.CPU
+	tmpos := rec.OS
+	tmplatdeg := rec.LatDeg
+	tmplatmin := rec.LatMin
+	tmplatsec := rec.LatSec
+	tmplatdir := rec.LatDir
+	tmplongdeg := rec.LongDeg
+	tmplongmin := rec.LongMin
+	tmplongsec := rec.LongSec
+	tmplongdir := rec.LongDir
+	tmpaltitude := rec.Altitude
+	tmpsize := rec.Size
+	tmphprecision := rec.HPrecision
+	tmpvprecision := rec.VPrecision
+	tmpsmimeausage := rec.SmimeaUsage
+	tmpsmimeaselector := rec.SmimeaSelector
+	tmpsmimeamatchingtype := rec.SmimeaMatchingType
+	tmpgeodnscode := rec.GeodnsCode
+	tmpgeodnslocation := rec.GeodnsLocation
+
+	return Record{
+		Domain:             domain,
+		ID:                 rec.ID,
+		Rtype:              rec.Rtype,
+		Host:               rec.Host,
+		TTL:                tmpttl,
+		Record:             rec.Record,
+		Priority:           tmppriority,
+		Frame:              tmpframe,
+		FrameTitle:         tmpframetitle,
+		FrameKeywords:      tmpframekeywords,
+		FrameDescription:   tmpframedescription,
+		MobileMeta:         tmpmobilemeta,
+		SavePath:           tmpsavepath,
+		RedirectType:       tmpredirecttype,
+		Weight:             tmpweight,
+		Port:               tmpport,
+		Mail:               tmpmail,
+		Txt:                tmptxt,
+		Algorithm:          tmpalgorithm,
+		Fptype:             tmpfptype,
+		Flag:               tmpflag,
+		Order:              tmporder,
+		Pref:               tmppref,
+		Params:             tmpparams,
+		Regexp:             tmpregexp,
+		Replace:            tmpreplace,
+		CaaFlag:            tmpcaaflag,
+		CaaType:            tmpcaatype,
+		CaaValue:           tmpcaavalue,
+		TlsaUsage:          tmptlsausage,
+		TlsaSelector:       tmptlsaselector,
+		TlsaMatchingType:   tmptlsamatchingtype,
+		KeyTag:             tmpkeytag,
+		DigestType:         tmpdigesttype,
+		CertType:           tmpcerttype,
+		CertKeyTag:         tmpcertkeytag,
+		CertAlgorithm:      tmpcertalgorithm,
+		CPU:                tmpcpu,
+		OS:                 tmpos,
+		LatDeg:             tmplatdeg,
+		LatMin:             tmplatmin,
+		LatSec:             tmplatsec,
+		LatDir:             tmplatdir,
+		LongDeg:            tmplongdeg,
+		LongMin:            tmplongmin,
+		LongSec:            tmplongsec,
+		LongDir:            tmplongdir,
+		Altitude:           tmpaltitude,
+		Size:               tmpsize,
+		HPrecision:         tmphprecision,
+		VPrecision:         tmpvprecision,
+		SmimeaUsage:        tmpsmimeausage,
+		SmimeaSelector:     tmpsmimeaselector,
+		SmimeaMatchingType: tmpsmimeamatchingtype,
+		GeodnsLocation:     tmpgeodnslocation,
+		GeodnsCode:         tmpgeodnscode,
+	}
+}
+
+// Create a new record
+func (r Record) Create(a *Apiaccess) (Record, error) {
+	return r.create(context.Background(), a)
+}
+
+func (r Record) create(ctx context.Context, a *Apiaccess) (Record, error) {
+	// only reject what is known to be unsupported, if the lookup fails the API decides
+	verr := r.Validate(ctx, a)
+	if errors.Is(verr, ErrUnsupportedTTL) || errors.Is(verr, ErrUnsupportedRecordType) {
+		return r, verr
+	}
+
+	inr := newCreaterec(r, a)
+	resp, err := inr.create(ctx)
+	if err == nil {
+		errmsg, isapierr := checkapierr(resp.Body())
+		if isapierr {
+			return r, errors.New(errmsg)
+		}
+		newid := gjson.GetBytes(resp.Body(), "data.id")
+		r.ID = newid.String()
+	}
+	return r, err
+}
+
+// Read a record
+func (r Record) Read(a *Apiaccess) (Record, error) {
+	lsr := reclist{
+		Authid:       a.Authid,
+		Subauthid:    a.Subauthid,
+		Subauthuser:  a.Subauthuser,
+		Authpassword: a.Authpassword,
+		Domain:       r.Domain,
+		Host:         r.Host,
+		Rtype:        r.Rtype,
+	}
+	resp, err := lsr.lsrec(context.Background())
+	if err == nil {
+		errmsg, isapierr := checkapierr(resp.Body())
+		if isapierr {
+			return r, errors.New(errmsg)
+		}
+		var ratmp map[string]retrec
+		err2 := json.Unmarshal(resp.Body(), &ratmp)
+		for _, rec := range ratmp {
+			rectmp := rec.record(r.Domain)
+			if r.ID != "" && r.ID == rectmp.ID {
+				return rectmp, err2
+			}
+
+			return rectmp, err2
+		}
+		return r, err2
+	}
+	return r, err
+}
+
+// Update a record
+func (r Record) Update(a *Apiaccess) (Record, error) {
+	return r.update(context.Background(), a)
+}
 
-	resp, err := inr.update()
+func (r Record) update(ctx context.Context, a *Apiaccess) (Record, error) {
+	inr := newUpdaterec(r, a)
+	resp, err := inr.update(ctx)
 	if err == nil {
 		errmsg, isapierr := checkapierr(resp.Body())
 		if isapierr {
@@ -738,10 +762,15 @@ func (r Record) Update(a *Apiaccess) (Record, error) {
 
 // Destroy a record
 func (r Record) Destroy(a *Apiaccess) (Record, error) {
+	return r.destroy(context.Background(), a)
+}
+
+func (r Record) destroy(ctx context.Context, a *Apiaccess) (Record, error) {
 	tmpid, _ := strconv.Atoi(r.ID)
 	inr := updaterec{
 		Authid:       a.Authid,
 		Subauthid:    a.Subauthid,
+		Subauthuser:  a.Subauthuser,
 		Authpassword: a.Authpassword,
 		Rid:          tmpid,
 		Domain:       r.Domain,
@@ -749,7 +778,7 @@ func (r Record) Destroy(a *Apiaccess) (Record, error) {
 		TTL:          r.TTL,
 		Record:       r.Record,
 	}
-	resp, err := inr.destroy()
+	resp, err := inr.destroy(ctx)
 	if err == nil {
 		errmsg, isapierr := checkapierr(resp.Body())
 		if isapierr {
@@ -765,6 +794,7 @@ func newActivateFailover(r Failover, a *Apiaccess) ActivateFailover {
 	return ActivateFailover{
 		Authid:           a.Authid,
 		Subauthid:        a.Subauthid,
+		Subauthuser:      a.Subauthuser,
 		Authpassword:     a.Authpassword,
 		Domain:           r.Domain,
 		RecordId:         r.RecordId,
@@ -772,11 +802,7 @@ func newActivateFailover(r Failover, a *Apiaccess) ActivateFailover {
 		DownEventHandler: r.DownEventHandler,
 		UpEventHandler:   r.UpEventHandler,
 		MainIP:           r.MainIP,
-		BackupIp1:        r.BackupIp1,
-		BackupIp2:        r.BackupIp2,
-		BackupIp3:        r.BackupIp3,
-		BackupIp4:        r.BackupIp4,
-		BackupIp5:        r.BackupIp5,
+		Backups:          r.Backups,
 		MonitoringRegion: r.MonitoringRegion,
 		Host:             r.CheckSettings.Host,
 		Port:             r.CheckSettings.Port,
@@ -794,9 +820,16 @@ func newActivateFailover(r Failover, a *Apiaccess) ActivateFailover {
 }
 
 func (f Failover) Create(a *Apiaccess) (Failover, error) {
+	return f.create(context.Background(), a)
+}
+
+func (f Failover) create(ctx context.Context, a *Apiaccess) (Failover, error) {
+	if err := f.Validate(); err != nil {
+		return f, err
+	}
 	inf := newActivateFailover(f, a)
 
-	resp, err := inf.create()
+	resp, err := inf.create(ctx)
 	if err == nil {
 		errmsg, isapierr := checkapierr(resp.Body())
 		if isapierr {
@@ -807,6 +840,9 @@ func (f Failover) Create(a *Apiaccess) (Failover, error) {
 }
 
 func (f Failover) Update(a *Apiaccess) (Failover, error) {
+	if err := f.Validate(); err != nil {
+		return f, err
+	}
 	inf := newActivateFailover(f, a)
 
 	resp, err := inf.update()
@@ -832,7 +868,6 @@ func (f Failover) Read(a *Apiaccess) (Failover, error) {
 	if len(body) == 0 {
 		return f, errors.New("empty response body")
 	}
-	fmt.Printf("Raw response body: %s\n", string(body))
 
 	var failoverData FailoverData
 	err = json.Unmarshal(body, &failoverData)
@@ -840,20 +875,14 @@ func (f Failover) Read(a *Apiaccess) (Failover, error) {
 		return f, fmt.Errorf("error unmarshalling response: %v", err)
 	}
 
-	fmt.Printf("Parsed Failover Data: %+v\n", failoverData)
-
 	f.FailoverType = failoverData.FailoverType
 	f.DownEventHandler = failoverData.DownEventHandler
 	f.UpEventHandler = failoverData.UpEventHandler
 	f.MainIP = failoverData.MainIP
 	f.CheckSettings.Timeout = defaultIfEmpty(failoverData.CheckSettings.Timeout)
-	f.BackupIp1 = defaultIfEmpty(failoverData.BackupIp1)
-	f.BackupIp2 = defaultIfEmpty(failoverData.BackupIp2)
-	f.BackupIp3 = defaultIfEmpty(failoverData.BackupIp3)
-	f.BackupIp4 = defaultIfEmpty(failoverData.BackupIp4)
-	f.BackupIp5 = defaultIfEmpty(failoverData.BackupIp5)
-	f.MonitoringRegion = defaultIfEmpty(failoverData.MonitoringRegion)
-	f.CheckPeriod = defaultIfEmpty(failoverData.CheckPeriod)
+	f.Backups = failoverData.Backups
+	f.MonitoringRegion = failoverData.MonitoringRegion
+	f.CheckPeriod = failoverData.CheckPeriod
 	f.CheckRegion = failoverData.CheckRegion
 	f.NotificationMail = defaultIfEmpty(failoverData.NotificationMail)
 	f.CheckSettings.Host = defaultIfEmpty(failoverData.CheckSettings.Host)
@@ -863,9 +892,7 @@ func (f Failover) Read(a *Apiaccess) (Failover, error) {
 	f.CheckSettings.QueryType = defaultIfEmpty(failoverData.CheckSettings.QueryType)
 	f.CheckSettings.QueryResponse = defaultIfEmpty(failoverData.CheckSettings.QueryResponse)
 	f.CheckSettings.LatencyLimit = defaultIfEmpty(failoverData.CheckSettings.LatencyLimit)
-	f.CheckSettings.HttpRequestType = defaultIfEmpty(failoverData.CheckSettings.HttpRequestType)
-
-	fmt.Printf("Here is the returned record: %+v\n", f)
+	f.CheckSettings.HttpRequestType = failoverData.CheckSettings.HttpRequestType
 
 	return f, nil
 }
@@ -873,7 +900,7 @@ func (f Failover) Read(a *Apiaccess) (Failover, error) {
 func (f Failover) Delete(a *Apiaccess) (Failover, error) {
 	inf := newActivateFailover(f, a)
 
-	resp, err := inf.destroy()
+	resp, err := inf.destroy(context.Background())
 	if err == nil {
 		errmsg, isapierr := checkapierr(resp.Body())
 		if isapierr {
@@ -894,6 +921,7 @@ func newDynamicUrlRequest(d DynamicUrl, a *Apiaccess) DynamicUrlRequest {
 	return DynamicUrlRequest{
 		Authid:       a.Authid,
 		Subauthid:    a.Subauthid,
+		Subauthuser:  a.Subauthuser,
 		Authpassword: a.Authpassword,
 		Domain:       d.Domain,
 		RecordId:     d.RecordId,
@@ -901,7 +929,10 @@ func newDynamicUrlRequest(d DynamicUrl, a *Apiaccess) DynamicUrlRequest {
 }
 
 func (d DynamicUrl) apireqWithResponse(a *Apiaccess, url string) (DynamicUrlResponse, error) {
-	var dynUrl DynamicUrlResponse
+	dynUrl := DynamicUrlResponse{
+		Domain:   d.Domain,
+		RecordId: d.RecordId,
+	}
 
 	req := newDynamicUrlRequest(d, a)
 	resp, err := apireq(url, req)
@@ -910,22 +941,24 @@ func (d DynamicUrl) apireqWithResponse(a *Apiaccess, url string) (DynamicUrlResp
 	}
 
 	body := resp.Body()
-
 	if len(body) == 0 {
 		return dynUrl, errors.New("empty response body")
 	}
-	fmt.Printf("Raw response body: %s\n", string(body))
+	if err := apierror(body); err != nil {
+		return dynUrl, err
+	}
 
 	err = json.Unmarshal(body, &dynUrl)
 	if err != nil {
 		return dynUrl, fmt.Errorf("error unmarshalling response: %v", err)
 	}
+	if dynUrl.Url == "" {
+		return dynUrl, errors.New("no dynamic url in response")
+	}
 
 	dynUrl.Domain = d.Domain
 	dynUrl.RecordId = d.RecordId
 
-	fmt.Printf("Parsed Dynamic Url Data: %+v\n", dynUrl)
-
 	return dynUrl, nil
 }
 
@@ -947,10 +980,7 @@ func (d DynamicUrl) Delete(a *Apiaccess) (DynamicUrlResponse, error) {
 	req := newDynamicUrlRequest(d, a)
 	resp, err := apireq("/dns/disable-dynamic-url.json", req)
 	if err == nil {
-		errmsg, isapierr := checkapierr(resp.Body())
-		if isapierr {
-			return dynUrl, errors.New(errmsg)
-		}
+		err = apierror(resp.Body())
 	}
 	return dynUrl, err
 }
@@ -1,44 +1,40 @@
 package cloudns
 
 import (
+	"encoding/json"
 	"reflect"
 	"testing"
 )
 
 func TestCreateActivateFailover(t *testing.T) {
 	t.Run("Ping check", func(t *testing.T) {
+		var sent map[string]interface{}
+		newTestAPI(t, func(path string, body map[string]interface{}) interface{} {
+			if path != "/dns/failover-activate.json" {
+				t.Errorf("Unexpected path %s", path)
+			}
+			sent = body
+			return map[string]string{"status": "Success", "statusDescription": "Failover is activated."}
+		})
 
-		validApiAccess := &Apiaccess{
-			Authid:       24325,
-			Authpassword: "123456",
-		}
-		expectedResult := activatefailover{
-			Domain:           "testzone.bg",
-			RecordId:         "518569025",
-			FailoverType:     1,
-			MainIP:           "192.168.0.1",
-			Timeout:          3,
-			LatencyLimit:     5,
-			BackupIp1:        "192.168.0.5",
-			UpEventHandler:   2,
-			DownEventHandler: 2,
+		expectedResult := Failover{
+			Domain:       "testzone.bg",
+			RecordId:     "518569025",
+			FailoverType: CheckPing,
+			MainIP:       "192.168.0.1",
+			CheckSettings: CheckSettings{
+				Timeout:      "3",
+				LatencyLimit: "5",
+			},
+			Backups:          []string{"192.168.0.5"},
+			UpEventHandler:   UpMainIP,
+			DownEventHandler: DownBackupIP,
 			NotificationMail: "venkoul99@gmail.com",
 		}
 
-		afInstance := activatefailover{
-			Domain:           "testzone.bg",
-			RecordId:         "518569025",
-			FailoverType:     1,
-			MainIP:           "192.168.0.1",
-			Timeout:          3,
-			LatencyLimit:     5,
-			BackupIp1:        "192.168.0.5",
-			UpEventHandler:   2,
-			DownEventHandler: 2,
-			NotificationMail: "venkoul99@gmail.com",
-		}
+		afInstance := expectedResult
 
-		result, err := afInstance.Create(validApiAccess)
+		result, err := afInstance.Create(testApiAccess)
 		if err != nil {
 			t.Errorf("Expected no error, got %v", err)
 		}
@@ -46,43 +42,37 @@ func TestCreateActivateFailover(t *testing.T) {
 		if !reflect.DeepEqual(result, expectedResult) {
 			t.Errorf("Expected result %v, got %v", expectedResult, result)
 		}
+		if sent["check_type"] != float64(1) || sent["down_event_handler"] != float64(2) || sent["backup_ip_1"] != "192.168.0.5" {
+			t.Errorf("Unexpected request %v", sent)
+		}
 	})
 
 	t.Run("HTTP check", func(t *testing.T) {
+		var sent map[string]interface{}
+		newTestAPI(t, func(path string, body map[string]interface{}) interface{} {
+			sent = body
+			return map[string]string{"status": "Success", "statusDescription": "Failover is activated."}
+		})
 
-		validApiAccess := &Apiaccess{
-			Authid:       24325,
-			Authpassword: "123456",
-		}
-		expectedResult := activatefailover{
-			Domain:           "testzone.bg",
-			RecordId:         "518752375",
-			FailoverType:     4,
-			MainIP:           "192.168.0.2",
-			Host:             "radioflix.org",
-			Port:             443,
-			Path:             "somepath",
-			HttpRequestType:  "GET",
-			UpEventHandler:   1,
-			DownEventHandler: 1,
+		expectedResult := Failover{
+			Domain:       "testzone.bg",
+			RecordId:     "518752375",
+			FailoverType: CheckHTTP,
+			MainIP:       "192.168.0.2",
+			CheckSettings: CheckSettings{
+				Host:            "radioflix.org",
+				Port:            443,
+				Path:            "somepath",
+				HttpRequestType: HttpGET,
+			},
+			UpEventHandler:   UpActivate,
+			DownEventHandler: DownDeactivate,
 			NotificationMail: "venkoul99@gmail.com",
 		}
 
-		afInstance := activatefailover{
-			Domain:           "testzone.bg",
-			RecordId:         "518752375",
-			FailoverType:     4,
-			MainIP:           "192.168.0.2",
-			Host:             "radioflix.org",
-			Port:             443,
-			Path:             "somepath",
-			HttpRequestType:  "GET",
-			UpEventHandler:   1,
-			DownEventHandler: 1,
-			NotificationMail: "venkoul99@gmail.com",
-		}
+		afInstance := expectedResult
 
-		result, err := afInstance.Create(validApiAccess)
+		result, err := afInstance.Create(testApiAccess)
 		if err != nil {
 			t.Errorf("Expected no error, got %v", err)
 		}
@@ -90,6 +80,222 @@ func TestCreateActivateFailover(t *testing.T) {
 		if !reflect.DeepEqual(result, expectedResult) {
 			t.Errorf("Expected result %v, got %v", expectedResult, result)
 		}
+		if sent["host"] != "radioflix.org" || sent["http_request_type"] != "GET" {
+			t.Errorf("Unexpected request %v", sent)
+		}
+	})
+
+	t.Run("IPv6 main IP on an A record", func(t *testing.T) {
+		newTestAPI(t, func(path string, body map[string]interface{}) interface{} {
+			t.Errorf("Unexpected path %s", path)
+			return nil
+		})
+
+		f := Failover{
+			Domain:       "testzone.bg",
+			RecordId:     "518569025",
+			FailoverType: CheckPing,
+			MainIP:       "2001:db8::1",
+			Backups:      []string{"2001:db8::2"},
+			RecordType:   "A",
+		}
+		if _, err := f.Create(testApiAccess); err == nil {
+			t.Errorf("Expected an error for IPv6 addresses on an A record")
+		}
+		f.RecordType = "CNAME"
+		if _, err := f.Update(testApiAccess); err == nil {
+			t.Errorf("Expected an error for a failover on a CNAME record")
+		}
+	})
+}
+
+func TestFailoverValidate(t *testing.T) {
+	base := Failover{
+		Domain:   "testzone.bg",
+		RecordId: "518569025",
+		MainIP:   "192.168.0.1",
+	}
+	tests := []struct {
+		name  string
+		edit  func(f *Failover)
+		valid bool
+	}{
+		{"ping", func(f *Failover) { f.FailoverType = CheckPing }, true},
+		{"no check type", func(f *Failover) {}, false},
+		{"HTTP without host", func(f *Failover) { f.FailoverType = CheckHTTP }, false},
+		{"HTTPS content without content", func(f *Failover) {
+			f.FailoverType = CheckHTTPSContent
+			f.CheckSettings.Host = "example.com"
+		}, false},
+		{"TCP with port", func(f *Failover) {
+			f.FailoverType = CheckTCP
+			f.CheckSettings.Port = 22
+		}, true},
+		{"DNS without query", func(f *Failover) {
+			f.FailoverType = CheckDNS
+			f.CheckSettings.Host = "example.com"
+		}, false},
+		{"backup IP handler without backup", func(f *Failover) {
+			f.FailoverType = CheckPing
+			f.DownEventHandler = DownBackupIP
+		}, false},
+		{"monitoring region", func(f *Failover) {
+			f.FailoverType = CheckPing
+			f.MonitoringRegion = RegionEurope
+		}, true},
+		{"IPv4 backup of an IPv6 main IP", func(f *Failover) {
+			f.FailoverType = CheckPing
+			f.MainIP = "2001:db8::1"
+			f.Backups = []string{"192.168.0.5"}
+		}, false},
+		{"AAAA record with IPv4 main IP", func(f *Failover) {
+			f.FailoverType = CheckPing
+			f.RecordType = "AAAA"
+		}, false},
+		{"unknown monitoring region", func(f *Failover) {
+			f.FailoverType = CheckPing
+			f.MonitoringRegion = "mars"
+		}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := base
+			tt.edit(&f)
+			err := f.Validate()
+			if tt.valid && err != nil {
+				t.Errorf("Expected no error, got %v", err)
+			}
+			if !tt.valid && err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	}
+}
+
+func TestReadFailoverStringFields(t *testing.T) {
+	newTestAPI(t, func(path string, body map[string]interface{}) interface{} {
+		return map[string]interface{}{
+			"check_type":         "4",
+			"down_event_handler": "1",
+			"up_event_handler":   "1",
+			"main_ip":            "192.168.0.2",
+			"check_period":       "300",
+			"check_settings": map[string]interface{}{
+				"host":              "radioflix.org",
+				"port":              "443",
+				"http_request_type": "GET",
+			},
+		}
 	})
 
+	f, err := Failover{Domain: "testzone.bg", RecordId: "518752375"}.Read(testApiAccess)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if f.FailoverType != CheckHTTP || f.DownEventHandler != DownDeactivate || f.CheckPeriod != CheckEvery5Minutes || f.CheckSettings.Port != 443 {
+		t.Errorf("Unexpected failover %+v", f)
+	}
+}
+
+func TestFailoverBackups(t *testing.T) {
+	f := Failover{
+		Domain:       "testzone.bg",
+		RecordId:     "518569025",
+		FailoverType: CheckPing,
+		MainIP:       "2001:db8::1",
+		Backups:      []string{"2001:db8::2", "2001:db8::3", "2001:db8::4", "2001:db8::5", "2001:db8::6", "2001:db8::7"},
+	}
+	if err := f.Validate(); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+
+	b, err := json.Marshal(newActivateFailover(f, testApiAccess))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	var sent map[string]interface{}
+	json.Unmarshal(b, &sent)
+	if sent["backup_ip_6"] != "2001:db8::7" || sent["auth-id"] != float64(24325) {
+		t.Errorf("Unexpected wire format %s", b)
+	}
+
+	var fd FailoverData
+	if err := json.Unmarshal([]byte(`{"main_ip":"192.168.0.1","backup_ip_1":"192.168.0.5","backup_ip_2":"","backup_ip_3":"192.168.0.7"}`), &fd); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !reflect.DeepEqual(fd.Backups, []string{"192.168.0.5", "192.168.0.7"}) {
+		t.Errorf("Unexpected backups %v", fd.Backups)
+	}
+
+	backups := []string{"192.168.0.5", "192.168.0.6"}
+	for _, v := range []interface{}{
+		&Failover{MainIP: "192.168.0.1", Backups: backups},
+		&ApiFailover{MainIP: "192.168.0.1", Backups: backups},
+		&FailoverData{MainIP: "192.168.0.1", Backups: backups},
+		&ActivateFailover{MainIP: "192.168.0.1", Backups: backups},
+	} {
+		b, err := json.Marshal(v)
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		var wire map[string]interface{}
+		json.Unmarshal(b, &wire)
+		if wire["backup_ip_2"] != "192.168.0.6" || wire["backups"] != nil {
+			t.Errorf("Unexpected wire format of %T: %s", v, b)
+		}
+		back := reflect.New(reflect.TypeOf(v).Elem()).Interface()
+		if err := json.Unmarshal(b, back); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if !reflect.DeepEqual(back, v) {
+			t.Errorf("Expected %+v after the round trip, got %+v", v, back)
+		}
+	}
+
+	if err := f.CheckBackups("AAAA"); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	f.Backups = append(f.Backups, "192.168.0.5")
+	if err := f.CheckBackups("AAAA"); err == nil {
+		t.Errorf("Expected an error for an IPv4 backup of an AAAA record")
+	}
+	if err := f.CheckBackups("A"); err == nil {
+		t.Errorf("Expected an error for IPv6 addresses in an A record")
+	}
+}
+
+func TestZoneStatus(t *testing.T) {
+	var sent map[string]interface{}
+	newTestAPI(t, func(path string, body map[string]interface{}) interface{} {
+		sent = body
+		switch path {
+		case "/dns/change-status.json":
+			return map[string]string{"status": "Success"}
+		case "/dns/list-zones.json":
+			return []map[string]string{
+				{"name": "testzone.bg", "type": "master", "status": "0"},
+				{"name": "other.bg", "type": "master", "status": "1"},
+			}
+		}
+		t.Errorf("Unexpected path %s", path)
+		return nil
+	})
+
+	z, err := Zone{Domain: "testzone.bg", Active: true}.Disable(testApiAccess)
+	if err != nil || z.Active || sent["status"] != 0.0 || sent["domain-name"] != "testzone.bg" {
+		t.Errorf("Unexpected disable %+v %v %v", z, err, sent)
+	}
+	z, err = z.Enable(testApiAccess)
+	if err != nil || !z.Active || sent["status"] != 1.0 {
+		t.Errorf("Unexpected enable %+v %v %v", z, err, sent)
+	}
+
+	z, err = Zone{Domain: "testzone.bg"}.Read(testApiAccess)
+	if err != nil || z.Active {
+		t.Errorf("Expected an inactive zone, got %+v %v", z, err)
+	}
+	zones, err := testApiAccess.Listzones()
+	if err != nil || len(zones) != 2 || zones[0].Active || !zones[1].Active {
+		t.Errorf("Unexpected zones %+v %v", zones, err)
+	}
 }
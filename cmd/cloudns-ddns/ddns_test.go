@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"path/filepath"
+	"testing"
+)
+
+type fakeDetector struct {
+	addr netip.Addr
+	err  error
+}
+
+func (d *fakeDetector) Detect(ctx context.Context, family int) (netip.Addr, error) {
+	return d.addr, d.err
+}
+
+type fakeUpdater struct {
+	updates []netip.Addr
+}
+
+func (u *fakeUpdater) Update(ctx context.Context, addr netip.Addr) error {
+	u.updates = append(u.updates, addr)
+	return nil
+}
+
+func TestAgentUpdatesOnlyOnChange(t *testing.T) {
+	statePath := filepath.Join(t.TempDir(), "state.json")
+	det := &fakeDetector{addr: netip.MustParseAddr("192.0.2.1")}
+	upd := &fakeUpdater{}
+	ag := &agent{detector: det, updater: upd, domain: "example.com", recordID: "123456", family: 4, statePath: statePath}
+
+	for range 2 {
+		if _, err := ag.runOnce(context.Background()); err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	}
+	if len(upd.updates) != 1 {
+		t.Errorf("Expected one update, got %v", upd.updates)
+	}
+
+	// a restarted agent remembers the address
+	st, err := loadState(statePath)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !st.forRecord("example.com", "123456", 4) || st.forRecord("example.com", "123456", 6) || st.forRecord("example.com", "654321", 4) {
+		t.Errorf("Unexpected state %+v", st)
+	}
+	ag = &agent{detector: det, updater: upd, domain: "example.com", recordID: "123456", family: 4, statePath: statePath, state: st}
+	det.addr = netip.MustParseAddr("192.0.2.2")
+	changed, err := ag.runOnce(context.Background())
+	if err != nil || !changed {
+		t.Errorf("Expected an update, got %v %v", changed, err)
+	}
+	if changed, _ = ag.runOnce(context.Background()); changed {
+		t.Errorf("Expected no update for the same address")
+	}
+	if len(upd.updates) != 2 {
+		t.Errorf("Expected two updates, got %v", upd.updates)
+	}
+
+	det.err = errors.New("offline")
+	if _, err := ag.runOnce(context.Background()); err == nil {
+		t.Errorf("Expected the detector error")
+	}
+}
+
+func TestDefaultStatePath(t *testing.T) {
+	a := defaultStatePath("example.com", "123456", 4)
+	if a == defaultStatePath("example.com", "123456", 6) || a == defaultStatePath("example.com", "654321", 4) {
+		t.Errorf("Expected a state file per record and family, got %s", a)
+	}
+}
+
+func TestDynamicURLResponse(t *testing.T) {
+	var body string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, body)
+	}))
+	defer srv.Close()
+
+	u := &DynamicURLUpdater{url: srv.URL}
+	addr := netip.MustParseAddr("127.0.0.1")
+	body = "OK"
+	if err := u.Update(context.Background(), addr); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	body = "Invalid request."
+	if err := u.Update(context.Background(), addr); err == nil {
+		t.Errorf("Expected an error for %q", body)
+	}
+}
+
+func TestPickAddr(t *testing.T) {
+	addrs := []net.Addr{
+		&net.IPNet{IP: net.ParseIP("127.0.0.1"), Mask: net.CIDRMask(8, 32)},
+		&net.IPNet{IP: net.ParseIP("192.168.1.10"), Mask: net.CIDRMask(24, 32)},
+		&net.IPNet{IP: net.ParseIP("fe80::1"), Mask: net.CIDRMask(64, 128)},
+		&net.IPNet{IP: net.ParseIP("2001:db8::10"), Mask: net.CIDRMask(64, 128)},
+		&net.IPNet{IP: net.ParseIP("198.51.100.7"), Mask: net.CIDRMask(24, 32)},
+	}
+	if ip, err := pickAddr(addrs, 4); err != nil || ip.String() != "198.51.100.7" {
+		t.Errorf("Unexpected IPv4 %v %v", ip, err)
+	}
+	if ip, err := pickAddr(addrs, 6); err != nil || ip.String() != "2001:db8::10" {
+		t.Errorf("Unexpected IPv6 %v %v", ip, err)
+	}
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+
+	cloudns "github.com/ClouDNS/cloudns-go"
+)
+
+// Detector finds the current address of the host for one IP family
+type Detector interface {
+	Detect(ctx context.Context, family int) (netip.Addr, error)
+}
+
+// HTTPDetector asks a web service that answers with the client address in plain text
+type HTTPDetector struct {
+	URL4 string
+	URL6 string
+}
+
+func (d HTTPDetector) Detect(ctx context.Context, family int) (netip.Addr, error) {
+	u := d.URL4
+	if family == 6 {
+		u = d.URL6
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return netip.Addr{}, err
+	}
+	resp, err := familyClient(family).Do(req)
+	if err != nil {
+		return netip.Addr{}, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return netip.Addr{}, fmt.Errorf("%s: %s", u, resp.Status)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 256))
+	if err != nil {
+		return netip.Addr{}, err
+	}
+	return checkFamily(strings.TrimSpace(string(body)), family)
+}
+
+// APIDetector uses the address the ClouDNS API sees, only for IPv4
+type APIDetector struct {
+	Access cloudns.Apiaccess
+}
+
+func (d APIDetector) Detect(ctx context.Context, family int) (netip.Addr, error) {
+	ip, err := d.Access.MyIP(ctx)
+	if err != nil {
+		return netip.Addr{}, err
+	}
+	return checkFamily(ip, family)
+}
+
+// InterfaceDetector takes the first global unicast address of a network interface
+type InterfaceDetector struct {
+	Name string
+}
+
+func (d InterfaceDetector) Detect(ctx context.Context, family int) (netip.Addr, error) {
+	iface, err := net.InterfaceByName(d.Name)
+	if err != nil {
+		return netip.Addr{}, err
+	}
+	addrs, err := iface.Addrs()
+	if err != nil {
+		return netip.Addr{}, err
+	}
+	return pickAddr(addrs, family)
+}
+
+func pickAddr(addrs []net.Addr, family int) (netip.Addr, error) {
+	for _, a := range addrs {
+		prefix, err := netip.ParsePrefix(a.String())
+		if err != nil {
+			continue
+		}
+		ip := prefix.Addr().Unmap()
+		if ip.IsGlobalUnicast() && !ip.IsPrivate() && (ip.Is4() == (family == 4)) {
+			return ip, nil
+		}
+	}
+	return netip.Addr{}, fmt.Errorf("no public IPv%d address found", family)
+}
+
+func checkFamily(s string, family int) (netip.Addr, error) {
+	ip, err := netip.ParseAddr(s)
+	if err != nil {
+		return netip.Addr{}, fmt.Errorf("invalid address %q", s)
+	}
+	ip = ip.Unmap()
+	if ip.Is4() != (family == 4) {
+		return netip.Addr{}, fmt.Errorf("got %s, want an IPv%d address", ip, family)
+	}
+	return ip, nil
+}
+
+// familyClients only connect over IPv4 or IPv6, so services that look at
+// the client address see the wanted family
+var familyClients = map[int]*http.Client{
+	4: newFamilyClient("tcp4"),
+	6: newFamilyClient("tcp6"),
+}
+
+// familyClient returns the HTTP client for the address family
+func familyClient(family int) *http.Client {
+	if family == 6 {
+		return familyClients[6]
+	}
+	return familyClients[4]
+}
+
+func newFamilyClient(network string) *http.Client {
+	dialer := &net.Dialer{}
+	tr := http.DefaultTransport.(*http.Transport).Clone()
+	tr.DialContext = func(ctx context.Context, _, addr string) (net.Conn, error) {
+		return dialer.DialContext(ctx, network, addr)
+	}
+	return &http.Client{Transport: tr}
+}
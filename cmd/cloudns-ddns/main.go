@@ -0,0 +1,161 @@
+// Command cloudns-ddns keeps an A or AAAA record pointed at the public address of the host.
+//
+// It detects the address with a web service, the ClouDNS API or a network interface
+// and only updates the record when the address changed since the last run,
+// through the dynamic URL of the record or, if that fails, with Record.Update.
+//
+//	cloudns-ddns -domain example.com -record-id 123456 -family 4 -interval 5m
+//
+// Credentials are read from the environment or the credentials file, see cloudns.LoadCredentials.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	cloudns "github.com/ClouDNS/cloudns-go"
+)
+
+const (
+	minBackoff = 30 * time.Second
+	maxBackoff = 30 * time.Minute
+)
+
+type agent struct {
+	detector  Detector
+	updater   Updater
+	domain    string
+	recordID  string
+	family    int
+	statePath string
+	state     State
+}
+
+// runOnce updates the record if the address changed and reports whether it did
+func (ag *agent) runOnce(ctx context.Context) (bool, error) {
+	addr, err := ag.detector.Detect(ctx, ag.family)
+	if err != nil {
+		return false, fmt.Errorf("detecting address: %v", err)
+	}
+	if addr == ag.state.Addr {
+		return false, nil
+	}
+	if err := ag.updater.Update(ctx, addr); err != nil {
+		return false, fmt.Errorf("updating record to %s: %v", addr, err)
+	}
+	log.Printf("record updated from %s to %s", ag.state.Addr, addr)
+	ag.state = State{Domain: ag.domain, RecordId: ag.recordID, Family: ag.family, Addr: addr, Updated: time.Now().UTC()}
+	if err := saveState(ag.statePath, ag.state); err != nil {
+		// the record is updated, the next run updates it once more at worst
+		log.Printf("saving state: %v", err)
+	}
+	return true, nil
+}
+
+// run checks every interval, after errors it retries with exponential backoff
+func (ag *agent) run(ctx context.Context, interval time.Duration) {
+	var backoff time.Duration
+	for {
+		wait := interval
+		if _, err := ag.runOnce(ctx); err != nil {
+			backoff = min(max(backoff*2, minBackoff), maxBackoff)
+			wait = backoff
+			log.Printf("%v, retrying in %s", err, wait)
+		} else {
+			backoff = 0
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+	}
+}
+
+func main() {
+	domain := flag.String("domain", "", "zone of the record")
+	recordID := flag.String("record-id", "", "id of the A or AAAA record")
+	family := flag.Int("family", 4, "address family, 4 for A or 6 for AAAA records")
+	detector := flag.String("detector", "http", "how to detect the address: http, api or interface")
+	iface := flag.String("interface", "", "network interface for -detector interface")
+	url4 := flag.String("detect-url4", "https://api.ipify.org", "service answering with the IPv4 address")
+	url6 := flag.String("detect-url6", "https://api6.ipify.org", "service answering with the IPv6 address")
+	update := flag.String("update", "", "how to update the record: dynurl (falls back to record) or record,\ndefaults to record for -detector interface and to dynurl otherwise")
+	statePath := flag.String("state", "", "file the last address is stored in,\ndefaults to a file per record and family in the user cache directory")
+	interval := flag.Duration("interval", 5*time.Minute, "time between checks")
+	once := flag.Bool("once", false, "check once and exit")
+	flag.Parse()
+
+	if *domain == "" || *recordID == "" {
+		log.Fatal("-domain and -record-id are required")
+	}
+	if *family != 4 && *family != 6 {
+		log.Fatal("-family must be 4 or 6")
+	}
+	a, err := cloudns.LoadCredentials()
+	if err != nil {
+		log.Fatalf("loading credentials: %v", err)
+	}
+
+	if *statePath == "" {
+		*statePath = defaultStatePath(*domain, *recordID, *family)
+	}
+
+	ag := &agent{domain: *domain, recordID: *recordID, family: *family, statePath: *statePath}
+	switch *detector {
+	case "http":
+		ag.detector = HTTPDetector{URL4: *url4, URL6: *url6}
+	case "api":
+		ag.detector = APIDetector{Access: a}
+	case "interface":
+		if *iface == "" {
+			log.Fatal("-detector interface needs -interface")
+		}
+		ag.detector = InterfaceDetector{Name: *iface}
+	default:
+		log.Fatalf("unknown detector %q", *detector)
+	}
+	// the dynamic URL sets the address the call comes from, which need not be the interface address
+	if *update == "" && *detector == "interface" {
+		*update = "record"
+	} else if *update == "" {
+		*update = "dynurl"
+	}
+	record := RecordUpdater{Access: a, Domain: *domain, RecordId: *recordID}
+	switch *update {
+	case "dynurl":
+		ag.updater = &DynamicURLUpdater{
+			Access:   a,
+			Dyn:      cloudns.DynamicUrl{Domain: *domain, RecordId: *recordID},
+			Fallback: record,
+		}
+	case "record":
+		ag.updater = record
+	default:
+		log.Fatalf("unknown update method %q", *update)
+	}
+	if ag.state, err = loadState(*statePath); err != nil {
+		log.Fatalf("loading state: %v", err)
+	}
+	if ag.state != (State{}) && !ag.state.forRecord(*domain, *recordID, *family) {
+		log.Printf("%s is the state of record %s in %s (IPv%d), starting without state",
+			*statePath, ag.state.RecordId, ag.state.Domain, ag.state.Family)
+		ag.state = State{}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if *once {
+		if _, err := ag.runOnce(ctx); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+	ag.run(ctx, *interval)
+}
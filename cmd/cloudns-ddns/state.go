@@ -0,0 +1,74 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// State is what the agent remembers between runs
+type State struct {
+	Domain   string     `json:"domain"`
+	RecordId string     `json:"record_id"`
+	Family   int        `json:"family"`
+	Addr     netip.Addr `json:"addr"`
+	Updated  time.Time  `json:"updated"`
+}
+
+// forRecord reports whether the state was saved for the record and family,
+// a state of another record says nothing about this one
+func (st State) forRecord(domain, recordID string, family int) bool {
+	return st.Domain == domain && st.RecordId == recordID && st.Family == family
+}
+
+// defaultStatePath is a state file per record and family in the user cache
+// directory, so agents for different records or families do not share one
+func defaultStatePath(domain, recordID string, family int) string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	name := fmt.Sprintf("%s-%s-ipv%d.json", domain, recordID, family)
+	return filepath.Join(dir, "cloudns-ddns", strings.ReplaceAll(name, string(filepath.Separator), "_"))
+}
+
+func loadState(path string) (State, error) {
+	var st State
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return st, nil
+	}
+	if err != nil {
+		return st, err
+	}
+	return st, json.Unmarshal(b, &st)
+}
+
+// saveState writes the state through a temporary file so a crash never leaves half a file
+func saveState(path string, st State) error {
+	b, err := json.MarshalIndent(st, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/netip"
+	"strings"
+
+	cloudns "github.com/ClouDNS/cloudns-go"
+)
+
+// Updater points the record at a new address
+type Updater interface {
+	Update(ctx context.Context, addr netip.Addr) error
+}
+
+// DynamicURLUpdater calls the dynamic URL of the record over the address family,
+// ClouDNS sets the record to the address the call comes from.
+// If that fails the Fallback updater is used.
+type DynamicURLUpdater struct {
+	Access   cloudns.Apiaccess
+	Dyn      cloudns.DynamicUrl
+	Fallback Updater
+
+	url string
+}
+
+func (u *DynamicURLUpdater) Update(ctx context.Context, addr netip.Addr) error {
+	err := u.call(ctx, addr)
+	if err == nil || u.Fallback == nil {
+		return err
+	}
+	log.Printf("dynamic URL update failed, updating the record instead: %v", err)
+	return u.Fallback.Update(ctx, addr)
+}
+
+func (u *DynamicURLUpdater) call(ctx context.Context, addr netip.Addr) error {
+	if u.url == "" {
+		dyn, err := u.Dyn.ReadOrCreate(&u.Access)
+		if err != nil {
+			return err
+		}
+		if dyn.Url == "" {
+			return fmt.Errorf("no dynamic URL for record %s", u.Dyn.RecordId)
+		}
+		u.url = dyn.Url
+	}
+
+	family := 4
+	if addr.Is6() {
+		family = 6
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := familyClient(family).Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("dynamic URL: %s", resp.Status)
+	}
+	// errors like an unknown or disabled URL come with status 200 as well
+	if msg := strings.TrimSpace(string(body)); !strings.HasPrefix(strings.ToUpper(msg), "OK") {
+		return fmt.Errorf("dynamic URL: %q", msg)
+	}
+	return nil
+}
+
+// RecordUpdater sets the record value with Record.Update
+type RecordUpdater struct {
+	Access   cloudns.Apiaccess
+	Domain   string
+	RecordId string
+}
+
+func (u RecordUpdater) Update(ctx context.Context, addr netip.Addr) error {
+	records, err := cloudns.Zone{Domain: u.Domain}.List(&u.Access)
+	if err != nil {
+		return err
+	}
+	for _, r := range records {
+		if r.ID != u.RecordId {
+			continue
+		}
+		if (r.Rtype == "A") != addr.Is4() {
+			return fmt.Errorf("can not set %s record %s to %s", r.Rtype, r.ID, addr)
+		}
+		r.Record = addr.String()
+		_, err = r.Update(&u.Access)
+		return err
+	}
+	return fmt.Errorf("record %s not found in %s", u.RecordId, u.Domain)
+}
@@ -0,0 +1,141 @@
+// Package cloudns copy records between zones
+package cloudns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// CopyFrom copies all records of the source zone into the zone, deleteExisting removes
+// the records of the zone first. If the API refuses to copy between the zones, e.g. for
+// zones of different types, it falls back to CopyRecordsFrom, other errors are returned.
+func (z Zone) CopyFrom(ctx context.Context, a *Apiaccess, source Zone, deleteExisting bool) (Zone, error) {
+	cp := copyrecords{
+		Authid:       a.Authid,
+		Subauthid:    a.Subauthid,
+		Subauthuser:  a.Subauthuser,
+		Authpassword: a.Authpassword,
+		Domain:       z.Domain,
+		FromDomain:   source.Domain,
+	}
+	if deleteExisting {
+		cp.DeleteCurrent = 1
+	}
+	resp, err := cp.copy(ctx)
+	if err != nil {
+		return z, err
+	}
+	var apierr *APIError
+	if err := apierror(resp.Body()); errors.As(err, &apierr) {
+		if !copyrefused[strings.TrimSpace(apierr.Description)] {
+			return z, err
+		}
+		return z.CopyRecordsFrom(ctx, a, source, deleteExisting)
+	}
+	return z, nil
+}
+
+// copyrefused are the descriptions the API answers with when it does not copy
+// records between the two zones, as opposed to errors like bad credentials
+var copyrefused = map[string]bool{
+	"Records can not be copied.": true,
+}
+
+// CopyRecordsFrom copies the records of the source zone one by one with Record.Create,
+// names below the source zone are rewritten to the same names below the zone.
+// The NS records of the zone apex are left alone.
+//
+// All source records are validated before the zone is changed and records that are
+// already in the zone are not created again. With deleteExisting the other records of
+// the zone are only deleted after every record was created, so a failed copy never
+// leaves the zone emptied.
+func (z Zone) CopyRecordsFrom(ctx context.Context, a *Apiaccess, source Zone, deleteExisting bool) (Zone, error) {
+	records, err := source.list(ctx, a)
+	if err != nil {
+		return z, fmt.Errorf("listing records of %s: %v", source.Domain, err)
+	}
+	var copies []Record
+	for _, r := range records {
+		if isapexns(r) {
+			continue
+		}
+		r = rezone(r, source.Domain, z.Domain)
+		err := r.Validate(ctx, a)
+		if errors.Is(err, ErrUnsupportedTTL) || errors.Is(err, ErrUnsupportedRecordType) {
+			return z, fmt.Errorf("copying %s %s record: %w", r.Host, r.Rtype, err)
+		}
+		copies = append(copies, r)
+	}
+
+	existing, err := z.list(ctx, a)
+	if err != nil {
+		return z, fmt.Errorf("listing records of %s: %v", z.Domain, err)
+	}
+	keep := map[string]bool{}
+	for _, r := range copies {
+		if old, ok := findrecord(existing, r); ok {
+			keep[old.ID] = true
+			continue
+		}
+		if _, err := r.create(ctx, a); err != nil {
+			return z, fmt.Errorf("copying %s %s record: %v", r.Host, r.Rtype, err)
+		}
+	}
+	if !deleteExisting {
+		return z, nil
+	}
+	for _, r := range existing {
+		if isapexns(r) || keep[r.ID] {
+			continue
+		}
+		if _, err := r.destroy(ctx, a); err != nil {
+			return z, fmt.Errorf("deleting %s %s record %s: %v", r.Host, r.Rtype, r.ID, err)
+		}
+	}
+	return z, nil
+}
+
+// findrecord returns the record of records with the same host, type and value as r
+func findrecord(records []Record, r Record) (Record, bool) {
+	for _, old := range records {
+		if strings.EqualFold(old.Host, r.Host) && old.Rtype == r.Rtype && old.Record == r.Record &&
+			old.TTL == r.TTL && old.Priority == r.Priority && old.Weight == r.Weight && old.Port == r.Port {
+			return old, true
+		}
+	}
+	return Record{}, false
+}
+
+func isapexns(r Record) bool {
+	return r.Rtype == "NS" && (r.Host == "" || r.Host == "@")
+}
+
+// rezone moves a record of zone from to zone to, including targets of
+// CNAME, MX, SRV and similar records that point into the old zone
+func rezone(r Record, from string, to string) Record {
+	r.ID = ""
+	r.Domain = to
+	r.Host = rehost(r.Host, from, to)
+	switch r.Rtype {
+	case "CNAME", "MX", "NS", "SRV", "PTR", "ALIAS", "DNAME":
+		r.Record = rehost(r.Record, from, to)
+	}
+	return r
+}
+
+// rehost rewrites name if it is from or below from, a trailing dot is kept
+func rehost(name string, from string, to string) string {
+	fqdn := strings.TrimSuffix(name, ".")
+	dot := name[len(fqdn):]
+	from = strings.TrimSuffix(from, ".")
+	to = strings.TrimSuffix(to, ".")
+	switch lower := strings.ToLower(fqdn); {
+	case lower == strings.ToLower(from):
+		return to + dot
+	case strings.HasSuffix(lower, "."+strings.ToLower(from)):
+		return fqdn[:len(fqdn)-len(from)] + to + dot
+	}
+	return name
+}
@@ -0,0 +1,189 @@
+package cloudns
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestZoneCopyFrom(t *testing.T) {
+	resetAvailCache(t)
+
+	copyok := true
+	createok := true
+	var calls []string
+	var created []map[string]interface{}
+	var deleted []interface{}
+	var sent map[string]interface{}
+	newTestAPI(t, func(path string, body map[string]interface{}) interface{} {
+		switch path {
+		case "/dns/copy-records.json":
+			sent = body
+			if copyok {
+				return map[string]string{"status": "Success", "statusDescription": "3 records were copied."}
+			}
+			return map[string]string{"status": "Failed", "statusDescription": "Records can not be copied."}
+		case "/dns/get-zone-info.json":
+			return zoneInfoResponse(body)
+		case "/dns/get-available-ttl.json":
+			return []int{300, 3600}
+		case "/dns/get-available-record-types.json":
+			return []string{"A", "CNAME", "MX", "NS", "TXT"}
+		case "/dns/records.json":
+			if body["domain-name"] == "source.bg" {
+				return map[string]interface{}{
+					"1": map[string]string{"id": "1", "type": "NS", "host": "", "record": "ns1.cloudns.net", "ttl": "3600"},
+					"2": map[string]string{"id": "2", "type": "CNAME", "host": "www", "record": "web.source.bg", "ttl": "300"},
+					"3": map[string]string{"id": "3", "type": "MX", "host": "", "record": "mx.source.bg.", "ttl": "3600", "priority": "10"},
+					"4": map[string]string{"id": "4", "type": "TXT", "host": "", "record": "v=spf1 include:source.bg -all", "ttl": "3600"},
+				}
+			}
+			return map[string]interface{}{
+				"8":  map[string]string{"id": "8", "type": "NS", "host": "", "record": "ns1.cloudns.net", "ttl": "3600"},
+				"9":  map[string]string{"id": "9", "type": "A", "host": "old", "record": "192.0.2.1", "ttl": "3600"},
+				"10": map[string]string{"id": "10", "type": "TXT", "host": "", "record": "v=spf1 include:source.bg -all", "ttl": "3600"},
+			}
+		case "/dns/delete-record.json":
+			calls = append(calls, path)
+			deleted = append(deleted, body["record-id"])
+			return map[string]string{"status": "Success"}
+		case "/dns/add-record.json":
+			if !createok {
+				return map[string]string{"status": "Failed", "statusDescription": "Invalid record."}
+			}
+			calls = append(calls, path)
+			created = append(created, body)
+			return map[string]interface{}{"status": "Success", "data": map[string]int{"id": 100}}
+		}
+		t.Errorf("Unexpected path %s", path)
+		return nil
+	})
+
+	z := Zone{Domain: "target.bg"}
+	if _, err := z.CopyFrom(context.Background(), testApiAccess, Zone{Domain: "source.bg"}, true); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if sent["domain-name"] != "target.bg" || sent["from-domain"] != "source.bg" || sent["delete-current-records"] != 1.0 {
+		t.Errorf("Unexpected copy request %v", sent)
+	}
+	if len(created) != 0 {
+		t.Errorf("Expected no records created, got %v", created)
+	}
+
+	copyok = false
+	if _, err := z.CopyFrom(context.Background(), testApiAccess, Zone{Domain: "source.bg"}, true); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	// record 10 is the same as the TXT record of the source and is kept
+	if len(deleted) != 1 || deleted[0] != 9.0 {
+		t.Errorf("Expected record 9 deleted, got %v", deleted)
+	}
+	if len(calls) != 3 || calls[2] != "/dns/delete-record.json" {
+		t.Errorf("Expected the records created before any is deleted, got %v", calls)
+	}
+	want := map[string]string{"CNAME": "web.target.bg", "MX": "mx.target.bg."}
+	if len(created) != len(want) {
+		t.Fatalf("Expected %d records created, got %v", len(want), created)
+	}
+	for _, c := range created {
+		if c["domain-name"] != "target.bg" || c["record"] != want[c["record-type"].(string)] {
+			t.Errorf("Unexpected record %v", c)
+		}
+	}
+
+	// without deleteExisting records the zone already has are not created again
+	created, deleted = nil, nil
+	if _, err := z.CopyFrom(context.Background(), testApiAccess, Zone{Domain: "source.bg"}, false); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(created) != len(want) || len(deleted) != 0 {
+		t.Errorf("Expected %d records created and none deleted, got %v %v", len(want), created, deleted)
+	}
+
+	// a failed create leaves the records of the zone alone
+	createok = false
+	deleted = nil
+	if _, err := z.CopyFrom(context.Background(), testApiAccess, Zone{Domain: "source.bg"}, true); err == nil {
+		t.Errorf("Expected an error for the failed create")
+	}
+	if len(deleted) != 0 {
+		t.Errorf("Expected no records deleted, got %v", deleted)
+	}
+}
+
+func TestZoneCopyFromFailures(t *testing.T) {
+	resetAvailCache(t)
+
+	var copydesc string
+	var paths []string
+	newTestAPI(t, func(path string, body map[string]interface{}) interface{} {
+		paths = append(paths, path)
+		switch path {
+		case "/dns/copy-records.json":
+			return map[string]string{"status": "Failed", "statusDescription": copydesc}
+		case "/dns/get-zone-info.json":
+			return zoneInfoResponse(body)
+		case "/dns/get-available-ttl.json":
+			return []int{300, 3600}
+		case "/dns/get-available-record-types.json":
+			return []string{"A", "CNAME"}
+		case "/dns/records.json":
+			if body["domain-name"] == "source.bg" {
+				return map[string]interface{}{
+					"1": map[string]string{"id": "1", "type": "A", "host": "", "record": "192.0.2.1", "ttl": "3600"},
+					"2": map[string]string{"id": "2", "type": "CNAME", "host": "www", "record": "source.bg", "ttl": "60"},
+				}
+			}
+			return map[string]interface{}{
+				"9": map[string]string{"id": "9", "type": "A", "host": "old", "record": "192.0.2.1", "ttl": "3600"},
+			}
+		}
+		t.Errorf("Unexpected path %s", path)
+		return nil
+	})
+
+	z := Zone{Domain: "target.bg"}
+
+	// only the refusal to copy falls back to copying record by record
+	copydesc = "Invalid authentication, incorrect auth-id or auth-password."
+	_, err := z.CopyFrom(context.Background(), testApiAccess, Zone{Domain: "source.bg"}, true)
+	var apierr *APIError
+	if !errors.As(err, &apierr) || len(paths) != 1 {
+		t.Errorf("Expected the API error without fallback, got %v after %v", err, paths)
+	}
+
+	// a record that can not be copied stops the copy before the zone is changed
+	copydesc = "Records can not be copied."
+	paths = nil
+	_, err = z.CopyFrom(context.Background(), testApiAccess, Zone{Domain: "source.bg"}, true)
+	if !errors.Is(err, ErrUnsupportedTTL) {
+		t.Errorf("Expected ErrUnsupportedTTL, got %v", err)
+	}
+	for _, p := range paths {
+		if p == "/dns/add-record.json" || p == "/dns/delete-record.json" {
+			t.Errorf("Expected no changes to the zone, got %v", paths)
+		}
+	}
+}
+
+func TestRehost(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"", ""},
+		{"www", "www"},
+		{"source.bg", "target.bg"},
+		{"Mail.Source.BG.", "Mail.target.bg."},
+		{"a.b.source.bg", "a.b.target.bg"},
+		{"othersource.bg", "othersource.bg"},
+		{"source.bg.example.com", "source.bg.example.com"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rehost(tt.name, "source.bg", "target.bg"); got != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,259 @@
+// Package cloudns credential loading
+package cloudns
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Environment variables consulted by CredentialsFromEnv and DefaultCredentialsChain
+const (
+	EnvAuthID           = "CLOUDNS_AUTH_ID"
+	EnvSubAuthID        = "CLOUDNS_SUB_AUTH_ID"
+	EnvSubAuthUser      = "CLOUDNS_SUB_AUTH_USER"
+	EnvAuthPassword     = "CLOUDNS_AUTH_PASSWORD"
+	EnvAuthPasswordFile = "CLOUDNS_AUTH_PASSWORD_FILE"
+	EnvCredentialsFile  = "CLOUDNS_CREDENTIALS_FILE"
+	EnvProfile          = "CLOUDNS_PROFILE"
+)
+
+// DefaultProfile is the profile used when none is given
+const DefaultProfile = "default"
+
+// ErrNoCredentials is returned by a provider that has nothing configured,
+// a CredentialsChain moves on to the next provider when it sees it
+var ErrNoCredentials = errors.New("no ClouDNS credentials found")
+
+// CredentialsProvider returns API credentials from some source
+type CredentialsProvider interface {
+	Credentials() (Apiaccess, error)
+}
+
+// Credentials returns the Apiaccess itself, so static credentials can be used in a chain
+func (a Apiaccess) Credentials() (Apiaccess, error) {
+	return a, nil
+}
+
+// EnvCredentials loads credentials from the CLOUDNS_* environment variables
+type EnvCredentials struct{}
+
+// Credentials see CredentialsFromEnv
+func (EnvCredentials) Credentials() (Apiaccess, error) {
+	return CredentialsFromEnv()
+}
+
+// FileCredentials loads credentials from a profile in a credentials file,
+// empty fields fall back to CLOUDNS_CREDENTIALS_FILE / CLOUDNS_PROFILE and the defaults
+type FileCredentials struct {
+	Path    string
+	Profile string
+}
+
+// Credentials see CredentialsFromFile
+func (f FileCredentials) Credentials() (Apiaccess, error) {
+	path := f.Path
+	if path == "" {
+		path = os.Getenv(EnvCredentialsFile)
+	}
+	if path == "" {
+		path = DefaultCredentialsFile()
+	}
+	if path == "" {
+		return Apiaccess{}, ErrNoCredentials
+	}
+	profile := f.Profile
+	if profile == "" {
+		profile = os.Getenv(EnvProfile)
+	}
+	a, err := CredentialsFromFile(path, profile)
+	// a missing default file just means nothing is configured
+	if errors.Is(err, os.ErrNotExist) && f.Path == "" && os.Getenv(EnvCredentialsFile) == "" {
+		return a, ErrNoCredentials
+	}
+	return a, err
+}
+
+// CredentialsChain asks each provider in turn and returns the first credentials found
+type CredentialsChain []CredentialsProvider
+
+// Credentials returns the first provider result that is not ErrNoCredentials
+func (c CredentialsChain) Credentials() (Apiaccess, error) {
+	for _, p := range c {
+		a, err := p.Credentials()
+		if errors.Is(err, ErrNoCredentials) {
+			continue
+		}
+		return a, err
+	}
+	return Apiaccess{}, ErrNoCredentials
+}
+
+// DefaultCredentialsChain consults the environment first, then the credentials file
+func DefaultCredentialsChain() CredentialsChain {
+	return CredentialsChain{EnvCredentials{}, FileCredentials{}}
+}
+
+// NewApiaccess returns the credentials of the provider for the API methods,
+// a nil provider consults DefaultCredentialsChain. The credentials are validated.
+func NewApiaccess(p CredentialsProvider) (*Apiaccess, error) {
+	if p == nil {
+		p = DefaultCredentialsChain()
+	}
+	a, err := p.Credentials()
+	if err != nil {
+		return nil, err
+	}
+	if err := a.Validate(); err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+// LoadCredentials returns validated credentials from the default chain
+func LoadCredentials() (Apiaccess, error) {
+	a, err := NewApiaccess(nil)
+	if err != nil {
+		return Apiaccess{}, err
+	}
+	return *a, nil
+}
+
+// DefaultCredentialsFile returns ~/.cloudns/credentials, or "" if there is no home directory
+func DefaultCredentialsFile() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".cloudns", "credentials")
+}
+
+// CredentialsFromEnv reads CLOUDNS_AUTH_ID, CLOUDNS_SUB_AUTH_ID or CLOUDNS_SUB_AUTH_USER
+// together with CLOUDNS_AUTH_PASSWORD, or CLOUDNS_AUTH_PASSWORD_FILE for mounted secrets
+func CredentialsFromEnv() (Apiaccess, error) {
+	var a Apiaccess
+	authid := os.Getenv(EnvAuthID)
+	subauthid := os.Getenv(EnvSubAuthID)
+	subauthuser := os.Getenv(EnvSubAuthUser)
+	if authid == "" && subauthid == "" && subauthuser == "" {
+		return a, ErrNoCredentials
+	}
+	vals := map[string]string{
+		"auth-id":            authid,
+		"sub-auth-id":        subauthid,
+		"sub-auth-user":      subauthuser,
+		"auth-password":      os.Getenv(EnvAuthPassword),
+		"auth-password-file": os.Getenv(EnvAuthPasswordFile),
+	}
+	a, err := credentialsFromValues(vals)
+	if err != nil {
+		return a, fmt.Errorf("credentials from environment: %v", err)
+	}
+	return a, nil
+}
+
+// CredentialsFromFile reads a profile from an ini style credentials file:
+//
+//	[default]
+//	auth-id = 1234
+//	auth-password = secret
+//
+//	[customer]
+//	sub-auth-user = customer
+//	auth-password-file = /var/run/secrets/cloudns/password
+func CredentialsFromFile(path string, profile string) (Apiaccess, error) {
+	var a Apiaccess
+	if profile == "" {
+		profile = DefaultProfile
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return a, err
+	}
+	defer f.Close()
+
+	profiles, err := parseCredentialsFile(f)
+	if err != nil {
+		return a, fmt.Errorf("%s: %v", path, err)
+	}
+	vals, ok := profiles[profile]
+	if !ok {
+		return a, fmt.Errorf("%s: profile %q not found", path, profile)
+	}
+	a, err = credentialsFromValues(vals)
+	if err != nil {
+		return a, fmt.Errorf("%s: profile %q: %v", path, profile, err)
+	}
+	return a, nil
+}
+
+func parseCredentialsFile(f *os.File) (map[string]map[string]string, error) {
+	profiles := map[string]map[string]string{}
+	var cur map[string]string
+	sc := bufio.NewScanner(f)
+	lineno := 0
+	for sc.Scan() {
+		lineno++
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			name := strings.TrimSpace(line[1 : len(line)-1])
+			if _, ok := profiles[name]; !ok {
+				profiles[name] = map[string]string{}
+			}
+			cur = profiles[name]
+			continue
+		}
+		key, val, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected key = value", lineno)
+		}
+		if cur == nil {
+			return nil, fmt.Errorf("line %d: key outside of a [profile] section", lineno)
+		}
+		cur[strings.TrimSpace(key)] = strings.TrimSpace(val)
+	}
+	return profiles, sc.Err()
+}
+
+func credentialsFromValues(vals map[string]string) (Apiaccess, error) {
+	var a Apiaccess
+	var err error
+	if v := vals["auth-id"]; v != "" {
+		if a.Authid, err = strconv.Atoi(v); err != nil {
+			return a, fmt.Errorf("invalid auth-id %q", v)
+		}
+	}
+	if v := vals["sub-auth-id"]; v != "" {
+		if a.Subauthid, err = strconv.Atoi(v); err != nil {
+			return a, fmt.Errorf("invalid sub-auth-id %q", v)
+		}
+	}
+	a.Subauthuser = vals["sub-auth-user"]
+	a.Authpassword = vals["auth-password"]
+	if a.Authpassword == "" && vals["auth-password-file"] != "" {
+		if a.Authpassword, err = readPasswordFile(vals["auth-password-file"]); err != nil {
+			return a, err
+		}
+	}
+	return a, a.Validate()
+}
+
+// readPasswordFile reads a secret mounted as a file, e.g. from a Kubernetes secret volume
+func readPasswordFile(path string) (string, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	pw := strings.TrimRight(string(b), "\r\n")
+	if pw == "" {
+		return "", fmt.Errorf("password file %s is empty", path)
+	}
+	return pw, nil
+}
@@ -0,0 +1,182 @@
+package cloudns
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCredentialsFromEnv(t *testing.T) {
+	t.Run("Auth id and password", func(t *testing.T) {
+		t.Setenv(EnvAuthID, "1234")
+		t.Setenv(EnvAuthPassword, "secret")
+
+		a, err := CredentialsFromEnv()
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		expected := Apiaccess{Authid: 1234, Authpassword: "secret"}
+		if a != expected {
+			t.Errorf("Expected %+v, got %+v", expected, a)
+		}
+	})
+
+	t.Run("Password file", func(t *testing.T) {
+		pwfile := filepath.Join(t.TempDir(), "password")
+		if err := os.WriteFile(pwfile, []byte("from-file\n"), 0600); err != nil {
+			t.Fatal(err)
+		}
+		t.Setenv(EnvSubAuthID, "42")
+		t.Setenv(EnvAuthPasswordFile, pwfile)
+
+		a, err := CredentialsFromEnv()
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		expected := Apiaccess{Subauthid: 42, Authpassword: "from-file"}
+		if a != expected {
+			t.Errorf("Expected %+v, got %+v", expected, a)
+		}
+	})
+
+	t.Run("Sub auth user", func(t *testing.T) {
+		t.Setenv(EnvSubAuthUser, "customer")
+		t.Setenv(EnvAuthPassword, "secret")
+
+		a, err := CredentialsFromEnv()
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		expected := Apiaccess{Subauthuser: "customer", Authpassword: "secret"}
+		if a != expected {
+			t.Errorf("Expected %+v, got %+v", expected, a)
+		}
+	})
+
+	t.Run("Two identities", func(t *testing.T) {
+		t.Setenv(EnvAuthID, "1234")
+		t.Setenv(EnvSubAuthUser, "customer")
+		t.Setenv(EnvAuthPassword, "secret")
+
+		if _, err := CredentialsFromEnv(); err == nil {
+			t.Errorf("Expected an error when both auth-id and sub-auth-user are set")
+		}
+	})
+
+	t.Run("Nothing set", func(t *testing.T) {
+		t.Setenv(EnvAuthID, "")
+		t.Setenv(EnvSubAuthID, "")
+		t.Setenv(EnvSubAuthUser, "")
+
+		_, err := CredentialsFromEnv()
+		if !errors.Is(err, ErrNoCredentials) {
+			t.Errorf("Expected ErrNoCredentials, got %v", err)
+		}
+	})
+}
+
+func TestCredentialsFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "credentials")
+	content := `# ClouDNS credentials
+[default]
+auth-id = 1234
+auth-password = secret
+
+[customer]
+sub-auth-id = 5678
+auth-password = other
+`
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	a, err := CredentialsFromFile(path, "")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if expected := (Apiaccess{Authid: 1234, Authpassword: "secret"}); a != expected {
+		t.Errorf("Expected %+v, got %+v", expected, a)
+	}
+
+	a, err = CredentialsFromFile(path, "customer")
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if expected := (Apiaccess{Subauthid: 5678, Authpassword: "other"}); a != expected {
+		t.Errorf("Expected %+v, got %+v", expected, a)
+	}
+
+	if _, err = CredentialsFromFile(path, "missing"); err == nil {
+		t.Errorf("Expected an error for a missing profile")
+	}
+}
+
+func TestCredentialsChain(t *testing.T) {
+	t.Setenv(EnvAuthID, "")
+	t.Setenv(EnvSubAuthID, "")
+	t.Setenv(EnvSubAuthUser, "")
+	t.Setenv(EnvCredentialsFile, "")
+	t.Setenv("HOME", t.TempDir())
+
+	static := Apiaccess{Authid: 1, Authpassword: "static"}
+	chain := append(DefaultCredentialsChain(), static)
+
+	a, err := chain.Credentials()
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if a != static {
+		t.Errorf("Expected %+v, got %+v", static, a)
+	}
+}
+
+func TestNewApiaccess(t *testing.T) {
+	t.Setenv(EnvAuthID, "")
+	t.Setenv(EnvSubAuthID, "")
+	t.Setenv(EnvSubAuthUser, "")
+	t.Setenv(EnvCredentialsFile, "")
+	t.Setenv("HOME", t.TempDir())
+
+	if _, err := NewApiaccess(nil); !errors.Is(err, ErrNoCredentials) {
+		t.Errorf("Expected ErrNoCredentials, got %v", err)
+	}
+
+	t.Setenv(EnvSubAuthUser, "customer")
+	t.Setenv(EnvAuthPassword, "secret")
+	a, err := NewApiaccess(nil)
+	if err != nil || *a != (Apiaccess{Subauthuser: "customer", Authpassword: "secret"}) {
+		t.Errorf("Unexpected credentials %+v %v", a, err)
+	}
+
+	static := Apiaccess{Authid: 1, Subauthid: 2, Authpassword: "static"}
+	if _, err := NewApiaccess(CredentialsChain{static}); err == nil {
+		t.Errorf("Expected an error for invalid credentials")
+	}
+}
+
+func TestApiaccessValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		a     Apiaccess
+		valid bool
+	}{
+		{"auth-id", Apiaccess{Authid: 1, Authpassword: "pw"}, true},
+		{"sub-auth-id", Apiaccess{Subauthid: 2, Authpassword: "pw"}, true},
+		{"sub-auth-user", Apiaccess{Subauthuser: "user", Authpassword: "pw"}, true},
+		{"no identity", Apiaccess{Authpassword: "pw"}, false},
+		{"two identities", Apiaccess{Authid: 1, Subauthuser: "user", Authpassword: "pw"}, false},
+		{"no password", Apiaccess{Authid: 1}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.a.Validate()
+			if tt.valid && err != nil {
+				t.Errorf("Expected no error, got %v", err)
+			}
+			if !tt.valid && err == nil {
+				t.Errorf("Expected an error")
+			}
+		})
+	}
+}
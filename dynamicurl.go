@@ -0,0 +1,58 @@
+// Package cloudns dynamic url history
+package cloudns
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/tidwall/gjson"
+)
+
+// DynamicUrlHistoryEntry is one call of a dynamic URL
+type DynamicUrlHistoryEntry struct {
+	IP   string    `json:"ip"`
+	Time time.Time `json:"date"`
+}
+
+// History returns a page of the calls of the dynamic URL, newest first,
+// to see when and from where the record was last updated
+func (d DynamicUrl) History(a *Apiaccess, page int, perPage int) ([]DynamicUrlHistoryEntry, error) {
+	var rh []DynamicUrlHistoryEntry
+	req := newDynamicUrlRequest(d, a)
+	req.Page = max(page, 1)
+	req.Hits = perPage
+	if req.Hits <= 0 {
+		req.Hits = 100
+	}
+
+	resp, err := req.history()
+	if err != nil {
+		return rh, err
+	}
+	if err := apierror(resp.Body()); err != nil {
+		return rh, err
+	}
+	// a list or an object keyed by id, like other list endpoints
+	res := gjson.ParseBytes(resp.Body())
+	if !gjson.ValidBytes(resp.Body()) || (!res.IsArray() && !res.IsObject()) {
+		return nil, fmt.Errorf("error unmarshalling response: unexpected history %q", resp.Body())
+	}
+	var perr error
+	res.ForEach(func(_, ev gjson.Result) bool {
+		if !ev.IsObject() {
+			perr = fmt.Errorf("error unmarshalling response: unexpected history entry %s", ev.Raw)
+			return false
+		}
+		rh = append(rh, DynamicUrlHistoryEntry{
+			IP:   ev.Get("ip").String(),
+			Time: ParseAPITime(ev.Get("date").String()),
+		})
+		return true
+	})
+	if perr != nil {
+		return nil, perr
+	}
+	sort.SliceStable(rh, func(i, j int) bool { return rh[i].Time.After(rh[j].Time) })
+	return rh, nil
+}
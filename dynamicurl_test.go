@@ -0,0 +1,65 @@
+package cloudns
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestDynamicUrl(t *testing.T) {
+	newTestAPI(t, func(path string, body map[string]interface{}) interface{} {
+		if body["record-id"] == "1" {
+			return map[string]string{"status": "Failed", "statusDescription": "Invalid record-id param."}
+		}
+		switch path {
+		case "/dns/get-dynamic-url.json":
+			return map[string]string{"host": "home", "url": "https://ipv4.cloudns.net/api/dynamicURL/?q=abc"}
+		case "/dns/get-dynamic-url-history.json":
+			return []map[string]string{
+				{"ip": "192.0.2.1", "date": "2026-10-01 08:00:00"},
+				{"ip": "198.51.100.7", "date": "2026-10-02 09:30:00"},
+			}
+		}
+		t.Errorf("Unexpected path %s", path)
+		return nil
+	})
+
+	d := DynamicUrl{Domain: "testzone.bg", RecordId: "518569025"}
+	dyn, err := d.ReadOrCreate(testApiAccess)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if dyn.Url != "https://ipv4.cloudns.net/api/dynamicURL/?q=abc" || dyn.RecordId != d.RecordId {
+		t.Errorf("Unexpected response %+v", dyn)
+	}
+
+	hist, err := d.History(testApiAccess, 1, 10)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expected := DynamicUrlHistoryEntry{IP: "198.51.100.7", Time: time.Date(2026, 10, 2, 9, 30, 0, 0, time.UTC)}
+	if len(hist) != 2 || hist[0] != expected {
+		t.Errorf("Unexpected history %+v", hist)
+	}
+
+	_, err = DynamicUrl{Domain: "testzone.bg", RecordId: "1"}.ReadOrCreate(testApiAccess)
+	if !errors.Is(err, ErrRecordNotFound) {
+		t.Errorf("Expected ErrRecordNotFound, got %v", err)
+	}
+	var apierr *APIError
+	if !errors.As(err, &apierr) || apierr.Status != "Failed" {
+		t.Errorf("Expected an APIError, got %v", err)
+	}
+}
+
+func TestDynamicUrlHistoryUnparsable(t *testing.T) {
+	for _, resp := range []interface{}{"maintenance", []string{"x"}} {
+		newTestAPI(t, func(path string, body map[string]interface{}) interface{} {
+			return resp
+		})
+		hist, err := DynamicUrl{Domain: "testzone.bg", RecordId: "518569025"}.History(testApiAccess, 1, 10)
+		if err == nil {
+			t.Errorf("Expected an error for %v, got %+v", resp, hist)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+// Package cloudns error types
+package cloudns
+
+import (
+	"errors"
+	"strings"
+)
+
+// ErrRecordNotFound is matched by errors.Is when the API reports an unknown record id
+var ErrRecordNotFound = errors.New("record not found")
+
+// ErrZoneSetup is matched by errors.Is when Zone.Create registered the zone but
+// could not finish setting it up, the zone exists and is returned with the error
+var ErrZoneSetup = errors.New("zone created but not set up")
+
+// APIError is an error status returned by the API
+type APIError struct {
+	Status      string
+	Description string
+}
+
+func (e *APIError) Error() string {
+	if e.Description == "" {
+		return "ClouDNS API: " + e.Status
+	}
+	return e.Description
+}
+
+// recordnotfound are the descriptions the API answers with for record ids
+// that do not exist in the zone
+var recordnotfound = map[string]bool{
+	"Invalid record-id param.": true,
+}
+
+// Is lets errors.Is match ErrRecordNotFound on the API descriptions for unknown record ids
+func (e *APIError) Is(target error) bool {
+	return target == ErrRecordNotFound && recordnotfound[strings.TrimSpace(e.Description)]
+}
@@ -0,0 +1,25 @@
+package cloudns
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestAPIErrorIsRecordNotFound(t *testing.T) {
+	tests := []struct {
+		desc     string
+		notfound bool
+	}{
+		{"Invalid record-id param.", true},
+		{"Missing record-id param.", false},
+		{"Invalid record type.", false},
+		{"Missing record param.", false},
+		{"Invalid authentication, incorrect auth-id or auth-password.", false},
+	}
+	for _, tt := range tests {
+		err := error(&APIError{Status: "Failed", Description: tt.desc})
+		if errors.Is(err, ErrRecordNotFound) != tt.notfound {
+			t.Errorf("Expected errors.Is(%q, ErrRecordNotFound) to be %v", tt.desc, tt.notfound)
+		}
+	}
+}
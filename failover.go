@@ -0,0 +1,267 @@
+// Package cloudns failover types and validation
+package cloudns
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/netip"
+	"strconv"
+)
+
+// FailoverCheckType is the monitoring check of a failover, check_type in the API
+type FailoverCheckType int
+
+// Failover check types
+const (
+	CheckPing         FailoverCheckType = 1
+	CheckDNS          FailoverCheckType = 2
+	CheckTCP          FailoverCheckType = 3
+	CheckHTTP         FailoverCheckType = 4
+	CheckHTTPS        FailoverCheckType = 5
+	CheckHTTPContent  FailoverCheckType = 6 // HTTP with a string the response must contain
+	CheckHTTPSContent FailoverCheckType = 7 // HTTPS with a string the response must contain
+	CheckUDP          FailoverCheckType = 8
+	CheckSMTP         FailoverCheckType = 9
+)
+
+var checktypenames = map[FailoverCheckType]string{
+	CheckPing:         "ping",
+	CheckDNS:          "DNS",
+	CheckTCP:          "TCP",
+	CheckHTTP:         "HTTP",
+	CheckHTTPS:        "HTTPS",
+	CheckHTTPContent:  "HTTP with content",
+	CheckHTTPSContent: "HTTPS with content",
+	CheckUDP:          "UDP",
+	CheckSMTP:         "SMTP",
+}
+
+func (t FailoverCheckType) String() string {
+	if name, ok := checktypenames[t]; ok {
+		return name
+	}
+	return "check type " + strconv.Itoa(int(t))
+}
+
+func (t *FailoverCheckType) UnmarshalJSON(data []byte) error {
+	var fi flexint
+	if err := json.Unmarshal(data, &fi); err != nil {
+		return err
+	}
+	*t = FailoverCheckType(fi)
+	return nil
+}
+
+// FailoverDownHandler is what happens to the record when the check goes down
+type FailoverDownHandler int
+
+// Down event handlers
+const (
+	DownDoNothing  FailoverDownHandler = 0
+	DownDeactivate FailoverDownHandler = 1 // deactivate the record
+	DownBackupIP   FailoverDownHandler = 2 // replace the record value with a backup IP
+)
+
+func (h *FailoverDownHandler) UnmarshalJSON(data []byte) error {
+	var fi flexint
+	if err := json.Unmarshal(data, &fi); err != nil {
+		return err
+	}
+	*h = FailoverDownHandler(fi)
+	return nil
+}
+
+// FailoverUpHandler is what happens to the record when the check comes back up
+type FailoverUpHandler int
+
+// Up event handlers
+const (
+	UpDoNothing FailoverUpHandler = 0
+	UpActivate  FailoverUpHandler = 1 // activate the record again
+	UpMainIP    FailoverUpHandler = 2 // replace the record value with the main IP
+)
+
+func (h *FailoverUpHandler) UnmarshalJSON(data []byte) error {
+	var fi flexint
+	if err := json.Unmarshal(data, &fi); err != nil {
+		return err
+	}
+	*h = FailoverUpHandler(fi)
+	return nil
+}
+
+// CheckPeriod is the interval between two checks in seconds
+type CheckPeriod int
+
+// Check periods
+const (
+	CheckEveryMinute    CheckPeriod = 60
+	CheckEvery5Minutes  CheckPeriod = 300
+	CheckEvery10Minutes CheckPeriod = 600
+	CheckEvery15Minutes CheckPeriod = 900
+	CheckEvery30Minutes CheckPeriod = 1800
+	CheckEveryHour      CheckPeriod = 3600
+	CheckEvery6Hours    CheckPeriod = 21600
+	CheckEvery12Hours   CheckPeriod = 43200
+	CheckEveryDay       CheckPeriod = 86400
+)
+
+func (p *CheckPeriod) UnmarshalJSON(data []byte) error {
+	var fi flexint
+	if err := json.Unmarshal(data, &fi); err != nil {
+		return err
+	}
+	*p = CheckPeriod(fi)
+	return nil
+}
+
+// MonitoringRegion is the region the checks are run from, empty for the default
+type MonitoringRegion string
+
+// Monitoring regions
+const (
+	RegionDefault      MonitoringRegion = ""
+	RegionEurope       MonitoringRegion = "eu"
+	RegionNorthAmerica MonitoringRegion = "na"
+	RegionAsia         MonitoringRegion = "asia"
+)
+
+// Valid reports whether the region is one of the Region constants
+func (r MonitoringRegion) Valid() bool {
+	switch r {
+	case RegionDefault, RegionEurope, RegionNorthAmerica, RegionAsia:
+		return true
+	}
+	return false
+}
+
+// HttpRequestType is the method used by HTTP and HTTPS checks
+type HttpRequestType string
+
+// HTTP request types
+const (
+	HttpGET  HttpRequestType = "GET"
+	HttpPOST HttpRequestType = "POST"
+	HttpHEAD HttpRequestType = "HEAD"
+)
+
+// Validate checks that the fields needed by the check type are set and that
+// the main and backup IPs are addresses for RecordType, or of one family if it
+// is not set. Create and Update call it before anything is sent to the API.
+func (f Failover) Validate() error {
+	if f.Domain == "" || f.RecordId == "" {
+		return errors.New("failover needs a domain-name and a record-id")
+	}
+	if f.MainIP == "" {
+		return errors.New("failover needs a main_ip")
+	}
+	// without RecordType the backups can only be checked against the main IP
+	rtype := f.RecordType
+	if rtype == "" {
+		rtype = recordtypeforip(f.MainIP)
+		if rtype == "" {
+			return fmt.Errorf("main_ip %q is not an IP address", f.MainIP)
+		}
+	} else if rtype != "A" && rtype != "AAAA" {
+		return fmt.Errorf("failover needs an A or AAAA record, not %s", rtype)
+	}
+	if err := f.CheckBackups(rtype); err != nil {
+		return err
+	}
+
+	cs := f.CheckSettings
+	var missing []string
+	switch f.FailoverType {
+	case CheckPing, CheckSMTP:
+	case CheckDNS:
+		if cs.Host == "" {
+			missing = append(missing, "host")
+		}
+		if cs.QueryType == "" {
+			missing = append(missing, "query_type")
+		}
+		if cs.QueryResponse == "" {
+			missing = append(missing, "query_response")
+		}
+	case CheckTCP, CheckUDP:
+		if cs.Port == 0 {
+			missing = append(missing, "port")
+		}
+	case CheckHTTP, CheckHTTPS, CheckHTTPContent, CheckHTTPSContent:
+		if cs.Host == "" {
+			missing = append(missing, "host")
+		}
+		if f.FailoverType == CheckHTTPContent || f.FailoverType == CheckHTTPSContent {
+			if cs.Content == "" {
+				missing = append(missing, "content")
+			}
+		}
+		switch cs.HttpRequestType {
+		case "", HttpGET, HttpPOST, HttpHEAD:
+		default:
+			return fmt.Errorf("unsupported http_request_type %q", cs.HttpRequestType)
+		}
+	default:
+		// other check types have no settings we know to require
+		if f.FailoverType <= 0 {
+			return errors.New("failover needs a check_type")
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%s failover needs check_settings %v", f.FailoverType, missing)
+	}
+
+	if !f.MonitoringRegion.Valid() {
+		return fmt.Errorf("unsupported monitoring_region %q", f.MonitoringRegion)
+	}
+
+	switch f.DownEventHandler {
+	case DownDoNothing, DownDeactivate:
+	case DownBackupIP:
+		if len(f.Backups) == 0 {
+			return errors.New("down_event_handler replace with backup IP needs a backup IP")
+		}
+	default:
+		return fmt.Errorf("unsupported down_event_handler %d", f.DownEventHandler)
+	}
+	switch f.UpEventHandler {
+	case UpDoNothing, UpActivate, UpMainIP:
+	default:
+		return fmt.Errorf("unsupported up_event_handler %d", f.UpEventHandler)
+	}
+	return nil
+}
+
+// CheckBackups checks that the main IP and every backup IP is an address
+// for the record type, IPv4 for A and IPv6 for AAAA records
+func (f Failover) CheckBackups(rtype string) error {
+	for i, ip := range append([]string{f.MainIP}, f.Backups...) {
+		name := "main_ip"
+		if i > 0 {
+			name = "backup_ip_" + strconv.Itoa(i)
+		}
+		if ip == "" {
+			return fmt.Errorf("%s is empty", name)
+		}
+		if got := recordtypeforip(ip); got != rtype {
+			if got == "" {
+				return fmt.Errorf("%s %q is not an IP address", name, ip)
+			}
+			return fmt.Errorf("%s %q is not an address for a %s record", name, ip, rtype)
+		}
+	}
+	return nil
+}
+
+// recordtypeforip returns A for IPv4 and AAAA for IPv6 addresses, "" otherwise
+func recordtypeforip(ip string) string {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return ""
+	}
+	if addr.Unmap().Is4() {
+		return "A"
+	}
+	return "AAAA"
+}
@@ -0,0 +1,99 @@
+// Package cloudns manual failover control
+package cloudns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Manual control uses the same calls as Create and Delete: a paused failover is
+// deactivated, so no check switches the record back, and Resume activates it
+// again with the settings of f. Keep f, e.g. from Read, to resume with them.
+
+// Pause stops monitoring, the record keeps the IP it serves now
+func (f Failover) Pause(ctx context.Context, a *Apiaccess) (FailoverStatus, error) {
+	rs := FailoverStatus{Domain: f.Domain, RecordId: f.RecordId, State: FailoverUnknown}
+	resp, err := newActivateFailover(f, a).destroy(ctx)
+	if err != nil {
+		return rs, err
+	}
+	errmsg, isapierr := checkapierr(resp.Body())
+	if isapierr {
+		return rs, errors.New(errmsg)
+	}
+	r, err := f.record(ctx, a)
+	if err != nil {
+		return rs, err
+	}
+	rs.State = FailoverPaused
+	rs.ActiveIP = r.Record
+	rs.Since = time.Now().UTC()
+	return rs, nil
+}
+
+// SwitchTo points the record of a paused failover at ip without changing the
+// check settings, use it during incidents to force traffic to a backup IP.
+// Pause first, a running check may switch the record back.
+func (f Failover) SwitchTo(ctx context.Context, a *Apiaccess, ip string) (FailoverStatus, error) {
+	rs := FailoverStatus{Domain: f.Domain, RecordId: f.RecordId, State: FailoverUnknown}
+	r, err := f.record(ctx, a)
+	if err != nil {
+		return rs, err
+	}
+	if got := recordtypeforip(ip); got != r.Rtype {
+		return rs, fmt.Errorf("%q is not an address for the %s record %s", ip, r.Rtype, r.ID)
+	}
+	r.Record = ip
+	if _, err := r.update(ctx, a); err != nil {
+		return rs, err
+	}
+	rs.State = FailoverPaused
+	rs.ActiveIP = ip
+	rs.Since = time.Now().UTC()
+	return rs, nil
+}
+
+// SwitchToBackup switches to the backup IP with number n, counting from 1
+func (f Failover) SwitchToBackup(ctx context.Context, a *Apiaccess, n int) (FailoverStatus, error) {
+	if n < 1 || n > len(f.Backups) {
+		return FailoverStatus{Domain: f.Domain, RecordId: f.RecordId, State: FailoverUnknown}, fmt.Errorf("no backup IP %d", n)
+	}
+	return f.SwitchTo(ctx, a, f.Backups[n-1])
+}
+
+// SwitchToMain returns the record to the main IP, monitoring stays paused until Resume
+func (f Failover) SwitchToMain(ctx context.Context, a *Apiaccess) (FailoverStatus, error) {
+	return f.SwitchTo(ctx, a, f.MainIP)
+}
+
+// Resume activates the failover again with the settings of f. The state is
+// FailoverUnknown until the first check, ActiveIP is the IP the record serves.
+func (f Failover) Resume(ctx context.Context, a *Apiaccess) (FailoverStatus, error) {
+	rs := FailoverStatus{Domain: f.Domain, RecordId: f.RecordId, State: FailoverUnknown}
+	if _, err := f.create(ctx, a); err != nil {
+		return rs, err
+	}
+	r, err := f.record(ctx, a)
+	if err != nil {
+		return rs, err
+	}
+	rs.ActiveIP = r.Record
+	rs.Since = time.Now().UTC()
+	return rs, nil
+}
+
+// record reads the record the failover is attached to
+func (f Failover) record(ctx context.Context, a *Apiaccess) (Record, error) {
+	records, err := Zone{Domain: f.Domain}.list(ctx, a)
+	if err != nil {
+		return Record{}, err
+	}
+	for _, r := range records {
+		if r.ID == f.RecordId {
+			return r, nil
+		}
+	}
+	return Record{}, fmt.Errorf("record %s: %w", f.RecordId, ErrRecordNotFound)
+}
@@ -0,0 +1,71 @@
+package cloudns
+
+import (
+	"context"
+	"testing"
+)
+
+func TestFailoverControl(t *testing.T) {
+	value := "192.168.0.1"
+	var paths []string
+	var sent map[string]interface{}
+	newTestAPI(t, func(path string, body map[string]interface{}) interface{} {
+		paths = append(paths, path)
+		switch path {
+		case "/dns/records.json":
+			return map[string]interface{}{
+				"518569025": map[string]string{"id": "518569025", "type": "A", "host": "", "record": value, "ttl": "3600"},
+			}
+		case "/dns/mod-record.json":
+			sent = body
+			value = body["record"].(string)
+			return map[string]string{"status": "Success"}
+		case "/dns/failover-deactivate.json", "/dns/failover-activate.json":
+			sent = body
+			return map[string]string{"status": "Success"}
+		}
+		t.Errorf("Unexpected path %s", path)
+		return nil
+	})
+
+	ctx := context.Background()
+	f := Failover{
+		Domain:           "testzone.bg",
+		RecordId:         "518569025",
+		FailoverType:     CheckPing,
+		MainIP:           "192.168.0.1",
+		Backups:          []string{"192.168.0.5", "192.168.0.6"},
+		DownEventHandler: DownBackupIP,
+		UpEventHandler:   UpMainIP,
+	}
+
+	st, err := f.Pause(ctx, testApiAccess)
+	if err != nil || st.State != FailoverPaused || st.ActiveIP != "192.168.0.1" || paths[0] != "/dns/failover-deactivate.json" {
+		t.Errorf("Unexpected pause %+v %v %v", st, err, paths)
+	}
+
+	st, err = f.SwitchToBackup(ctx, testApiAccess, 2)
+	if err != nil || st.State != FailoverPaused || st.ActiveIP != "192.168.0.6" || sent["record-id"] != 518569025.0 || sent["record"] != "192.168.0.6" {
+		t.Errorf("Unexpected switch %+v %v %v", st, err, sent)
+	}
+	if _, err = f.SwitchToBackup(ctx, testApiAccess, 3); err == nil {
+		t.Errorf("Expected an error for a missing backup IP")
+	}
+	if _, err = f.SwitchTo(ctx, testApiAccess, "2001:db8::1"); err == nil {
+		t.Errorf("Expected an error for an IPv6 address on an A record")
+	}
+
+	st, err = f.SwitchToMain(ctx, testApiAccess)
+	if err != nil || st.ActiveIP != "192.168.0.1" || value != "192.168.0.1" {
+		t.Errorf("Unexpected switch to main %+v %v", st, err)
+	}
+
+	paths = nil
+	st, err = f.Resume(ctx, testApiAccess)
+	if err != nil || st.State != FailoverUnknown || st.ActiveIP != "192.168.0.1" || sent["backup_ip_2"] != "192.168.0.6" {
+		t.Errorf("Unexpected resume %+v %v %v", st, err, sent)
+	}
+	if paths[len(paths)-2] != "/dns/failover-activate.json" {
+		t.Errorf("Expected the failover activated again, got %v", paths)
+	}
+}
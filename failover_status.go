@@ -0,0 +1,123 @@
+// Package cloudns failover state and history
+package cloudns
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/tidwall/gjson"
+)
+
+// FailoverState is the result of the last failover check
+type FailoverState string
+
+// Failover states
+const (
+	FailoverUp      FailoverState = "up"
+	FailoverDown    FailoverState = "down"
+	FailoverPaused  FailoverState = "paused" // monitoring paused with Failover.Pause
+	FailoverUnknown FailoverState = "unknown"
+)
+
+// ParseFailoverState reads the state names and numbers the API and its callbacks use
+func ParseFailoverState(v string) FailoverState {
+	switch strings.ToLower(v) {
+	case "1", "up", "ok", "online":
+		return FailoverUp
+	case "0", "down", "fail", "failed", "offline":
+		return FailoverDown
+	case "paused":
+		return FailoverPaused
+	}
+	return FailoverUnknown
+}
+
+// FailoverStatus is the current state of a failover check
+type FailoverStatus struct {
+	Domain   string        `json:"domain-name"`
+	RecordId string        `json:"record-id"`
+	State    FailoverState `json:"state"`
+	ActiveIP string        `json:"active_ip"`
+	Since    time.Time     `json:"last_change"`
+}
+
+// FailoverHistoryEntry is one state change or action in the failover log
+type FailoverHistoryEntry struct {
+	Time     time.Time     `json:"date"`
+	State    FailoverState `json:"state"`
+	ActiveIP string        `json:"ip"`
+	Action   string        `json:"action"`
+}
+
+// Status returns whether the check is up or down, since when and which IP the record serves
+func (f Failover) Status(a *Apiaccess) (FailoverStatus, error) {
+	rs := FailoverStatus{Domain: f.Domain, RecordId: f.RecordId, State: FailoverUnknown}
+	inf := newActivateFailover(f, a)
+
+	resp, err := inf.status()
+	if err != nil {
+		return rs, err
+	}
+	errmsg, isapierr := checkapierr(resp.Body())
+	if isapierr {
+		return rs, errors.New(errmsg)
+	}
+	if len(resp.Body()) == 0 {
+		return rs, errors.New("empty response body")
+	}
+	return parsefailoverstatus(rs, gjson.ParseBytes(resp.Body())), nil
+}
+
+func parsefailoverstatus(rs FailoverStatus, res gjson.Result) FailoverStatus {
+	rs.State = ParseFailoverState(res.Get("state").String())
+	rs.ActiveIP = res.Get("active_ip").String()
+	rs.Since = ParseAPITime(res.Get("last_change").String())
+	return rs
+}
+
+// History returns a page of the failover action log, newest first
+func (f Failover) History(a *Apiaccess, page int, perPage int) ([]FailoverHistoryEntry, error) {
+	var rh []FailoverHistoryEntry
+	inf := newActivateFailover(f, a)
+	inf.Page = max(page, 1)
+	inf.Hits = perPage
+	if inf.Hits <= 0 {
+		inf.Hits = 100
+	}
+
+	resp, err := inf.history()
+	if err != nil {
+		return rh, err
+	}
+	errmsg, isapierr := checkapierr(resp.Body())
+	if isapierr {
+		return rh, errors.New(errmsg)
+	}
+	// a list or an object keyed by id, like other list endpoints
+	res := gjson.ParseBytes(resp.Body())
+	if !gjson.ValidBytes(resp.Body()) || (!res.IsArray() && !res.IsObject()) {
+		return nil, fmt.Errorf("error unmarshalling response: unexpected history %q", resp.Body())
+	}
+	var perr error
+	res.ForEach(func(_, ev gjson.Result) bool {
+		if !ev.IsObject() {
+			perr = fmt.Errorf("error unmarshalling response: unexpected history entry %s", ev.Raw)
+			return false
+		}
+		rh = append(rh, FailoverHistoryEntry{
+			Time:     ParseAPITime(ev.Get("date").String()),
+			State:    ParseFailoverState(ev.Get("state").String()),
+			ActiveIP: ev.Get("ip").String(),
+			Action:   ev.Get("action").String(),
+		})
+		return true
+	})
+	if perr != nil {
+		return nil, perr
+	}
+	sort.SliceStable(rh, func(i, j int) bool { return rh[i].Time.After(rh[j].Time) })
+	return rh, nil
+}
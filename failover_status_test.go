@@ -0,0 +1,61 @@
+package cloudns
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFailoverStatusAndHistory(t *testing.T) {
+	newTestAPI(t, func(path string, body map[string]interface{}) interface{} {
+		switch path {
+		case "/dns/failover-status.json":
+			return map[string]interface{}{"state": "DOWN", "active_ip": "192.168.0.5", "last_change": "2026-10-01 08:15:00"}
+		case "/dns/failover-history.json":
+			if body["page"] != float64(1) || body["rows-per-page"] != float64(100) {
+				t.Errorf("Unexpected paging %v", body)
+			}
+			return []map[string]interface{}{
+				{"date": "2026-09-30 22:00:00", "state": 1, "ip": "192.168.0.1", "action": "replaced with main IP"},
+				{"date": "2026-10-01 08:15:00", "state": 0, "ip": "192.168.0.5", "action": "replaced with backup IP"},
+			}
+		}
+		t.Errorf("Unexpected path %s", path)
+		return nil
+	})
+
+	f := Failover{Domain: "testzone.bg", RecordId: "518569025"}
+	st, err := f.Status(testApiAccess)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expected := FailoverStatus{
+		Domain:   "testzone.bg",
+		RecordId: "518569025",
+		State:    FailoverDown,
+		ActiveIP: "192.168.0.5",
+		Since:    time.Date(2026, 10, 1, 8, 15, 0, 0, time.UTC),
+	}
+	if st != expected {
+		t.Errorf("Expected %+v, got %+v", expected, st)
+	}
+
+	hist, err := f.History(testApiAccess, 0, 0)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(hist) != 2 || hist[0].State != FailoverDown || hist[1].State != FailoverUp || hist[1].ActiveIP != "192.168.0.1" {
+		t.Errorf("Unexpected history %+v", hist)
+	}
+}
+
+func TestFailoverHistoryUnparsable(t *testing.T) {
+	for _, resp := range []interface{}{"maintenance", []string{"x"}} {
+		newTestAPI(t, func(path string, body map[string]interface{}) interface{} {
+			return resp
+		})
+		hist, err := Failover{Domain: "testzone.bg", RecordId: "518569025"}.History(testApiAccess, 1, 10)
+		if err == nil {
+			t.Errorf("Expected an error for %v, got %+v", resp, hist)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+// Package cloudns zone groups
+package cloudns
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/tidwall/gjson"
+)
+
+// ZoneGroup is a named group of zones, e.g. all zones of one customer
+type ZoneGroup struct {
+	ID   int    `json:"id,omitempty"`
+	Name string `json:"name"`
+}
+
+func newZoneGroup(g ZoneGroup, a *Apiaccess) zonegroup {
+	return zonegroup{
+		Authid:       a.Authid,
+		Subauthid:    a.Subauthid,
+		Subauthuser:  a.Subauthuser,
+		Authpassword: a.Authpassword,
+		ID:           g.ID,
+		Name:         g.Name,
+	}
+}
+
+// List returns all zone groups of the account
+func (g ZoneGroup) List(a *Apiaccess) ([]ZoneGroup, error) {
+	var rg []ZoneGroup
+	resp, err := newZoneGroup(ZoneGroup{}, a).list()
+	if err != nil {
+		return rg, err
+	}
+	errmsg, isapierr := checkapierr(resp.Body())
+	if isapierr {
+		return rg, errors.New(errmsg)
+	}
+	intrg, err := unmarshallist[retgroup](resp.Body())
+	for _, tmp := range intrg {
+		rg = append(rg, ZoneGroup{ID: int(tmp.ID), Name: tmp.Name})
+	}
+	return rg, err
+}
+
+// Create a zone group, the API needs a zone to put into the new group
+func (g ZoneGroup) Create(a *Apiaccess, z Zone) (ZoneGroup, error) {
+	if g.Name == "" {
+		return g, errors.New("zone group needs a name")
+	}
+	ing := newZoneGroup(g, a)
+	ing.ID = 0
+	ing.Domain = z.Domain
+
+	resp, err := ing.add()
+	if err == nil {
+		errmsg, isapierr := checkapierr(resp.Body())
+		if isapierr {
+			return g, errors.New(errmsg)
+		}
+		if newid := gjson.GetBytes(resp.Body(), "data.id"); newid.Exists() {
+			g.ID = int(newid.Int())
+		}
+	}
+	return g, err
+}
+
+// Update renames the zone group
+func (g ZoneGroup) Update(a *Apiaccess) (ZoneGroup, error) {
+	if g.ID == 0 {
+		return g, errors.New("zone group needs an id")
+	}
+	ing := newZoneGroup(g, a)
+	ing.Name = ""
+	ing.NewName = g.Name
+
+	resp, err := ing.rename()
+	if err == nil {
+		errmsg, isapierr := checkapierr(resp.Body())
+		if isapierr {
+			return g, errors.New(errmsg)
+		}
+	}
+	return g, err
+}
+
+// Delete the zone group, its zones stay without a group
+func (g ZoneGroup) Delete(a *Apiaccess) (ZoneGroup, error) {
+	if g.ID == 0 {
+		return g, errors.New("zone group needs an id")
+	}
+	resp, err := newZoneGroup(ZoneGroup{ID: g.ID}, a).destroy()
+	if err == nil {
+		errmsg, isapierr := checkapierr(resp.Body())
+		if isapierr {
+			return g, errors.New(errmsg)
+		}
+	}
+	return g, err
+}
+
+// Zones returns the zones in the group (max: 100)
+func (g ZoneGroup) Zones(a *Apiaccess) ([]Zone, error) {
+	if g.ID == 0 {
+		return nil, errors.New("zone group needs an id")
+	}
+	return listzones(zonelist{
+		Authid:       a.Authid,
+		Subauthid:    a.Subauthid,
+		Subauthuser:  a.Subauthuser,
+		Authpassword: a.Authpassword,
+		Page:         1,
+		Hits:         100,
+		Gid:          g.ID,
+	})
+}
+
+// MoveToGroup moves the zone into the zone group, g needs the ID or the Name of
+// the group, Zone.Group is set to the name of the group
+func (z Zone) MoveToGroup(a *Apiaccess, g ZoneGroup) (Zone, error) {
+	g, err := findgroup(a, g)
+	if err != nil {
+		return z, err
+	}
+	ing := newZoneGroup(ZoneGroup{ID: g.ID}, a)
+	ing.Domain = z.Domain
+
+	resp, err := ing.change()
+	if err == nil {
+		errmsg, isapierr := checkapierr(resp.Body())
+		if isapierr {
+			return z, errors.New(errmsg)
+		}
+		z.Group = g.Name
+	}
+	return z, err
+}
+
+// findgroup fills in the ID or the Name of g from the groups of the account
+func findgroup(a *Apiaccess, g ZoneGroup) (ZoneGroup, error) {
+	if g.ID == 0 && g.Name == "" {
+		return g, errors.New("zone group needs an id or a name")
+	}
+	if g.ID != 0 && g.Name != "" {
+		return g, nil
+	}
+	groups, err := ZoneGroup{}.List(a)
+	if err != nil {
+		return g, err
+	}
+	for _, tmp := range groups {
+		if tmp.ID == g.ID || (g.ID == 0 && tmp.Name == g.Name) {
+			return tmp, nil
+		}
+	}
+	if g.ID != 0 {
+		return g, fmt.Errorf("no zone group with id %d", g.ID)
+	}
+	return g, fmt.Errorf("no zone group %q", g.Name)
+}
@@ -0,0 +1,89 @@
+package cloudns
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestZoneGroups(t *testing.T) {
+	var sent map[string]interface{}
+	newTestAPI(t, func(path string, body map[string]interface{}) interface{} {
+		sent = body
+		switch path {
+		case "/dns/list-groups.json":
+			return map[string]interface{}{"7": map[string]string{"id": "7", "name": "tenant-a"}}
+		case "/dns/add-group.json":
+			return map[string]interface{}{"status": "Success", "data": map[string]int{"id": 8}}
+		case "/dns/rename-group.json", "/dns/delete-group.json", "/dns/change-group.json":
+			return map[string]string{"status": "Success"}
+		case "/dns/list-zones.json":
+			return []map[string]string{{"name": "testzone.bg", "type": "master", "group": "tenant-a"}}
+		}
+		t.Errorf("Unexpected path %s", path)
+		return nil
+	})
+
+	groups, err := ZoneGroup{}.List(testApiAccess)
+	if err != nil || len(groups) != 1 || groups[0] != (ZoneGroup{ID: 7, Name: "tenant-a"}) {
+		t.Errorf("Unexpected groups %+v %v", groups, err)
+	}
+
+	g, err := ZoneGroup{Name: "tenant-b"}.Create(testApiAccess, Zone{Domain: "testzone.bg"})
+	if err != nil || g.ID != 8 || sent["name"] != "tenant-b" || sent["domain-name"] != "testzone.bg" {
+		t.Errorf("Unexpected create %+v %v %v", g, err, sent)
+	}
+
+	g.Name = "tenant-c"
+	if _, err = g.Update(testApiAccess); err != nil || sent["group-id"] != 8.0 || sent["new-name"] != "tenant-c" {
+		t.Errorf("Unexpected rename %v %v", err, sent)
+	}
+
+	z, err := Zone{Domain: "testzone.bg"}.MoveToGroup(testApiAccess, ZoneGroup{ID: 7})
+	if err != nil || z.Group != "tenant-a" || sent["group-id"] != 7.0 || sent["domain-name"] != "testzone.bg" {
+		t.Errorf("Unexpected move %+v %v %v", z, err, sent)
+	}
+
+	z, err = Zone{Domain: "testzone.bg"}.MoveToGroup(testApiAccess, ZoneGroup{Name: "tenant-a"})
+	if err != nil || z.Group != "tenant-a" || sent["group-id"] != 7.0 {
+		t.Errorf("Unexpected move by name %+v %v %v", z, err, sent)
+	}
+	if _, err = (Zone{Domain: "testzone.bg"}).MoveToGroup(testApiAccess, ZoneGroup{Name: "missing"}); err == nil {
+		t.Errorf("Expected an error for an unknown group")
+	}
+
+	zones, err := ZoneGroup{ID: 7}.Zones(testApiAccess)
+	if err != nil || len(zones) != 1 || zones[0].Group != "tenant-a" || sent["group-id"] != 7.0 {
+		t.Errorf("Unexpected zones %+v %v %v", zones, err, sent)
+	}
+
+	if _, err = g.Delete(testApiAccess); err != nil || sent["group-id"] != 8.0 {
+		t.Errorf("Unexpected delete %v %v", err, sent)
+	}
+	if _, err = (ZoneGroup{}).Delete(testApiAccess); err == nil {
+		t.Errorf("Expected an error without id")
+	}
+}
+
+func TestZoneCreateGroupFailure(t *testing.T) {
+	newTestAPI(t, func(path string, body map[string]interface{}) interface{} {
+		switch path {
+		case "/dns/register.json":
+			return map[string]string{"status": "Success", "statusDescription": "Domain zone testzone.bg was created successfully."}
+		case "/dns/list-groups.json":
+			return map[string]interface{}{"9": map[string]string{"id": "9", "name": "tenant-z"}}
+		case "/dns/change-group.json":
+			return map[string]string{"status": "Failed", "statusDescription": "Invalid group-id."}
+		}
+		t.Errorf("Unexpected path %s", path)
+		return nil
+	})
+
+	z, err := Zone{Domain: "testzone.bg", Ztype: "master", Group: "tenant-z"}.Create(testApiAccess)
+	if !errors.Is(err, ErrZoneSetup) || !strings.Contains(err.Error(), "Invalid group-id.") {
+		t.Errorf("Expected ErrZoneSetup, got %v", err)
+	}
+	if z.Domain != "testzone.bg" || z.Group != "" {
+		t.Errorf("Expected the created zone without group, got %+v", z)
+	}
+}
@@ -0,0 +1,177 @@
+// Package cloudns mail forwards
+package cloudns
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/tidwall/gjson"
+)
+
+// MailForward forwards the mails for Box@Host.Domain to Destination,
+// an empty Host is the zone itself
+type MailForward struct {
+	Domain      string `json:"domain-name"`
+	ID          string `json:"id,omitempty"`
+	Box         string `json:"box"`
+	Host        string `json:"host"`
+	Destination string `json:"destination"`
+	Active      bool   `json:"active"`
+}
+
+// MailForwardStats is the number of mail forwards on the account and the limit of the plan
+type MailForwardStats struct {
+	Forwards int `json:"forwards"`
+	Limit    int `json:"limit"`
+}
+
+func newMailForward(m MailForward, a *Apiaccess) mailforward {
+	return mailforward{
+		Authid:       a.Authid,
+		Subauthid:    a.Subauthid,
+		Subauthuser:  a.Subauthuser,
+		Authpassword: a.Authpassword,
+		Domain:       m.Domain,
+		ID:           m.ID,
+		Box:          m.Box,
+		Host:         m.Host,
+		Destination:  m.Destination,
+	}
+}
+
+// MailForwards returns the mail forwards of the zone
+func (z Zone) MailForwards(a *Apiaccess) ([]MailForward, error) {
+	return MailForward{Domain: z.Domain}.List(a)
+}
+
+// List returns all mail forwards of the zone
+func (m MailForward) List(a *Apiaccess) ([]MailForward, error) {
+	var rm []MailForward
+	resp, err := newMailForward(MailForward{Domain: m.Domain}, a).list()
+	if err != nil {
+		return rm, err
+	}
+	errmsg, isapierr := checkapierr(resp.Body())
+	if isapierr {
+		return rm, errors.New(errmsg)
+	}
+	intrm, err := unmarshallist[retmailforward](resp.Body())
+	for _, tmp := range intrm {
+		rm = append(rm, MailForward{
+			Domain:      m.Domain,
+			ID:          tmp.ID.String(),
+			Box:         tmp.Box,
+			Host:        tmp.Host,
+			Destination: tmp.Destination,
+			Active:      tmp.Status == 1,
+		})
+	}
+	return rm, err
+}
+
+// Create a new mail forward, it is active right away
+func (m MailForward) Create(a *Apiaccess) (MailForward, error) {
+	if err := m.validate(); err != nil {
+		return m, err
+	}
+	inm := newMailForward(m, a)
+	inm.ID = ""
+
+	resp, err := inm.add()
+	if err == nil {
+		errmsg, isapierr := checkapierr(resp.Body())
+		if isapierr {
+			return m, errors.New(errmsg)
+		}
+		if newid := gjson.GetBytes(resp.Body(), "data.id"); newid.Exists() {
+			m.ID = newid.String()
+		}
+		m.Active = true
+	}
+	return m, err
+}
+
+// Update box, host and destination of a mail forward by its ID
+func (m MailForward) Update(a *Apiaccess) (MailForward, error) {
+	if m.ID == "" {
+		return m, errors.New("mail forward needs an id")
+	}
+	if err := m.validate(); err != nil {
+		return m, err
+	}
+	resp, err := newMailForward(m, a).modify()
+	if err == nil {
+		errmsg, isapierr := checkapierr(resp.Body())
+		if isapierr {
+			return m, errors.New(errmsg)
+		}
+	}
+	return m, err
+}
+
+// Delete a mail forward by its ID
+func (m MailForward) Delete(a *Apiaccess) (MailForward, error) {
+	if m.ID == "" {
+		return m, errors.New("mail forward needs an id")
+	}
+	resp, err := newMailForward(MailForward{Domain: m.Domain, ID: m.ID}, a).destroy()
+	if err == nil {
+		errmsg, isapierr := checkapierr(resp.Body())
+		if isapierr {
+			return m, errors.New(errmsg)
+		}
+	}
+	return m, err
+}
+
+// Enable a disabled mail forward
+func (m MailForward) Enable(a *Apiaccess) (MailForward, error) {
+	return m.setstatus(a, true)
+}
+
+// Disable a mail forward without deleting it
+func (m MailForward) Disable(a *Apiaccess) (MailForward, error) {
+	return m.setstatus(a, false)
+}
+
+// Stats returns the number of mail forwards on the account and the limit of the plan
+func (m MailForward) Stats(a *Apiaccess) (MailForwardStats, error) {
+	resp, err := newMailForward(MailForward{}, a).stats()
+	if err != nil {
+		return MailForwardStats{}, err
+	}
+	ru, err := readusage(resp.Body())
+	return MailForwardStats{Forwards: int(ru.Count), Limit: int(ru.Limit)}, err
+}
+
+func (m MailForward) setstatus(a *Apiaccess, active bool) (MailForward, error) {
+	if m.ID == "" {
+		return m, errors.New("mail forward needs an id")
+	}
+	status := 0
+	if active {
+		status = 1
+	}
+	inm := newMailForward(MailForward{Domain: m.Domain, ID: m.ID}, a)
+	inm.Status = &status
+
+	resp, err := inm.changestatus()
+	if err == nil {
+		errmsg, isapierr := checkapierr(resp.Body())
+		if isapierr {
+			return m, errors.New(errmsg)
+		}
+		m.Active = active
+	}
+	return m, err
+}
+
+func (m MailForward) validate() error {
+	if m.Box == "" {
+		return errors.New("mail forward needs a box")
+	}
+	if !strings.Contains(m.Destination, "@") {
+		return errors.New("mail forward needs a destination mail address")
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package cloudns
+
+import (
+	"testing"
+)
+
+func TestMailForwards(t *testing.T) {
+	var sent map[string]interface{}
+	newTestAPI(t, func(path string, body map[string]interface{}) interface{} {
+		sent = body
+		switch path {
+		case "/dns/mail-forwards.json":
+			return map[string]interface{}{
+				"6": map[string]string{"id": "6", "box": "info", "host": "", "destination": "info@example.com", "status": "1"},
+				"7": map[string]string{"id": "7", "box": "sales", "host": "shop", "destination": "sales@example.com", "status": "0"},
+			}
+		case "/dns/add-mail-forward.json":
+			return map[string]interface{}{"status": "Success", "data": map[string]int{"id": 8}}
+		case "/dns/modify-mail-forward.json", "/dns/delete-mail-forward.json", "/dns/modify-mail-forward-status.json":
+			return map[string]string{"status": "Success"}
+		case "/dns/get-mail-forwards-stats.json":
+			return map[string]string{"count": "2", "limit": "10"}
+		}
+		t.Errorf("Unexpected path %s", path)
+		return nil
+	})
+
+	forwards, err := Zone{Domain: "testzone.bg"}.MailForwards(testApiAccess)
+	if err != nil || len(forwards) != 2 {
+		t.Fatalf("Unexpected mail forwards %+v %v", forwards, err)
+	}
+	if forwards[0] != (MailForward{Domain: "testzone.bg", ID: "6", Box: "info", Destination: "info@example.com", Active: true}) ||
+		forwards[1] != (MailForward{Domain: "testzone.bg", ID: "7", Box: "sales", Host: "shop", Destination: "sales@example.com"}) {
+		t.Errorf("Unexpected mail forwards %+v", forwards)
+	}
+
+	m, err := MailForward{Domain: "testzone.bg", Box: "support", Destination: "help@example.com"}.Create(testApiAccess)
+	if err != nil || m.ID != "8" || !m.Active || sent["box"] != "support" || sent["destination"] != "help@example.com" {
+		t.Errorf("Unexpected create %+v %v %v", m, err, sent)
+	}
+
+	m.Destination = "support@example.com"
+	if _, err = m.Update(testApiAccess); err != nil || sent["mail-forward-id"] != "8" || sent["destination"] != "support@example.com" {
+		t.Errorf("Unexpected update %v %v", err, sent)
+	}
+
+	m, err = m.Disable(testApiAccess)
+	if err != nil || m.Active || sent["status"] != 0.0 {
+		t.Errorf("Unexpected disable %+v %v %v", m, err, sent)
+	}
+	m, err = m.Enable(testApiAccess)
+	if err != nil || !m.Active || sent["status"] != 1.0 {
+		t.Errorf("Unexpected enable %+v %v %v", m, err, sent)
+	}
+
+	if _, err = m.Delete(testApiAccess); err != nil || sent["mail-forward-id"] != "8" {
+		t.Errorf("Unexpected delete %v %v", err, sent)
+	}
+
+	stats, err := MailForward{}.Stats(testApiAccess)
+	if err != nil || stats != (MailForwardStats{Forwards: 2, Limit: 10}) {
+		t.Errorf("Unexpected stats %+v %v", stats, err)
+	}
+
+	if _, err = (MailForward{Domain: "testzone.bg", Box: "x", Destination: "nomail"}).Create(testApiAccess); err == nil {
+		t.Errorf("Expected an error for an invalid destination")
+	}
+}
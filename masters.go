@@ -0,0 +1,156 @@
+// Package cloudns slave zone master servers
+package cloudns
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/tidwall/gjson"
+)
+
+// MasterServer is a server a slave zone transfers its records from
+type MasterServer struct {
+	Domain string `json:"domain-name"`
+	ID     string `json:"id,omitempty"`
+	IP     string `json:"master-ip"`
+}
+
+// ZoneTransferStatus is the result of the last transfer of a slave zone from its masters
+type ZoneTransferStatus struct {
+	Domain       string    `json:"domain-name"`
+	Success      bool      `json:"success"`
+	Serial       string    `json:"serial,omitempty"`
+	Master       string    `json:"master-ip,omitempty"`
+	LastTransfer time.Time `json:"last_transfer"`
+	Message      string    `json:"message,omitempty"`
+}
+
+func newMasterServer(m MasterServer, a *Apiaccess) masterserver {
+	return masterserver{
+		Authid:       a.Authid,
+		Subauthid:    a.Subauthid,
+		Subauthuser:  a.Subauthuser,
+		Authpassword: a.Authpassword,
+		Domain:       m.Domain,
+		MasterId:     m.ID,
+		Master:       m.IP,
+	}
+}
+
+// MasterServers returns the master servers of a slave zone
+func (z Zone) MasterServers(a *Apiaccess) ([]MasterServer, error) {
+	return MasterServer{Domain: z.Domain}.List(a)
+}
+
+// List returns all master servers of the slave zone
+func (m MasterServer) List(a *Apiaccess) ([]MasterServer, error) {
+	var rm []MasterServer
+	inm := newMasterServer(MasterServer{Domain: m.Domain}, a)
+
+	resp, err := inm.list()
+	if err != nil {
+		return rm, err
+	}
+	errmsg, isapierr := checkapierr(resp.Body())
+	if isapierr {
+		return rm, errors.New(errmsg)
+	}
+	intrm, err := unmarshallist[retmaster](resp.Body())
+	for _, tmp := range intrm {
+		rm = append(rm, MasterServer{
+			Domain: m.Domain,
+			ID:     tmp.ID.String(),
+			IP:     tmp.Master,
+		})
+	}
+	return rm, err
+}
+
+// Add a master server to the slave zone
+func (m MasterServer) Add(a *Apiaccess) (MasterServer, error) {
+	if net.ParseIP(m.IP) == nil {
+		return m, errors.New("master server needs a valid master-ip")
+	}
+	inm := newMasterServer(m, a)
+	inm.MasterId = ""
+
+	resp, err := inm.add()
+	if err == nil {
+		errmsg, isapierr := checkapierr(resp.Body())
+		if isapierr {
+			return m, errors.New(errmsg)
+		}
+		if newid := gjson.GetBytes(resp.Body(), "data.id"); newid.Exists() {
+			m.ID = newid.String()
+		}
+	}
+	return m, err
+}
+
+// Delete a master server by its ID
+func (m MasterServer) Delete(a *Apiaccess) (MasterServer, error) {
+	if m.ID == "" {
+		return m, errors.New("master server needs an id")
+	}
+	inm := newMasterServer(m, a)
+
+	resp, err := inm.destroy()
+	if err == nil {
+		errmsg, isapierr := checkapierr(resp.Body())
+		if isapierr {
+			return m, errors.New(errmsg)
+		}
+	}
+	return m, err
+}
+
+// Refresh asks the slave zone to transfer the records from its masters now
+func (z Zone) Refresh(a *Apiaccess) (Zone, error) {
+	up := zupdate{
+		Authid:       a.Authid,
+		Subauthid:    a.Subauthid,
+		Subauthuser:  a.Subauthuser,
+		Authpassword: a.Authpassword,
+		Domain:       z.Domain,
+	}
+	resp, err := up.refresh()
+	if err == nil {
+		errmsg, isapierr := checkapierr(resp.Body())
+		if isapierr {
+			return z, errors.New(errmsg)
+		}
+	}
+	return z, err
+}
+
+// TransferStatus returns the result of the last transfer from the masters
+func (z Zone) TransferStatus(a *Apiaccess) (ZoneTransferStatus, error) {
+	rs := ZoneTransferStatus{Domain: z.Domain}
+	up := zupdate{
+		Authid:       a.Authid,
+		Subauthid:    a.Subauthid,
+		Subauthuser:  a.Subauthuser,
+		Authpassword: a.Authpassword,
+		Domain:       z.Domain,
+	}
+	resp, err := up.transferstatus()
+	if err != nil {
+		return rs, err
+	}
+	errmsg, isapierr := checkapierr(resp.Body())
+	if isapierr {
+		return rs, errors.New(errmsg)
+	}
+	res := gjson.ParseBytes(resp.Body())
+	if !gjson.ValidBytes(resp.Body()) || !res.IsObject() {
+		return rs, fmt.Errorf("error unmarshalling response: unexpected transfer status %q", resp.Body())
+	}
+	rs.Success = res.Get("success").Bool()
+	rs.Serial = res.Get("serial").String()
+	rs.Master = res.Get("master-ip").String()
+	rs.LastTransfer = ParseAPITime(res.Get("last_transfer").String())
+	rs.Message = res.Get("message").String()
+	return rs, nil
+}
@@ -0,0 +1,70 @@
+package cloudns
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMasterServers(t *testing.T) {
+	var sent map[string]interface{}
+	newTestAPI(t, func(path string, body map[string]interface{}) interface{} {
+		sent = body
+		switch path {
+		case "/dns/master-servers.json":
+			return map[string]interface{}{
+				"7": map[string]interface{}{"id": 7, "master-ip": "192.0.2.53"},
+			}
+		case "/dns/add-master-server.json":
+			return map[string]interface{}{"status": "Success", "data": map[string]int{"id": 8}}
+		case "/dns/delete-master-server.json", "/dns/update-slave-zone.json":
+			return map[string]string{"status": "Success"}
+		case "/dns/slave-zone-status.json":
+			return map[string]interface{}{"success": 1, "serial": "2026100101", "master-ip": "192.0.2.53", "last_transfer": "2026-10-01 08:00:00"}
+		}
+		t.Errorf("Unexpected path %s", path)
+		return nil
+	})
+
+	z := Zone{Domain: "slave.bg", Ztype: "slave"}
+	list, err := z.MasterServers(testApiAccess)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(list) != 1 || list[0] != (MasterServer{Domain: "slave.bg", ID: "7", IP: "192.0.2.53"}) {
+		t.Errorf("Unexpected masters %+v", list)
+	}
+
+	m, err := MasterServer{Domain: z.Domain, IP: "198.51.100.53"}.Add(testApiAccess)
+	if err != nil || m.ID != "8" || sent["master-ip"] != "198.51.100.53" {
+		t.Errorf("Unexpected add %+v %v %v", m, err, sent)
+	}
+	if _, err = m.Delete(testApiAccess); err != nil || sent["master-id"] != "8" {
+		t.Errorf("Unexpected delete %v %v", err, sent)
+	}
+	if _, err = (MasterServer{Domain: z.Domain, IP: "not-an-ip"}).Add(testApiAccess); err == nil {
+		t.Errorf("Expected an error for an invalid master-ip")
+	}
+
+	if _, err = z.Refresh(testApiAccess); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	st, err := z.TransferStatus(testApiAccess)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if !st.Success || st.Serial != "2026100101" || !st.LastTransfer.Equal(time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected status %+v", st)
+	}
+}
+
+func TestTransferStatusUnparsable(t *testing.T) {
+	for _, resp := range []interface{}{"maintenance", []string{"x"}} {
+		newTestAPI(t, func(path string, body map[string]interface{}) interface{} {
+			return resp
+		})
+		st, err := Zone{Domain: "testzone.bg"}.TransferStatus(testApiAccess)
+		if err == nil {
+			t.Errorf("Expected an error for %v, got %+v", resp, st)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+// Package cloudns nameserver selection
+package cloudns
+
+import (
+	"slices"
+	"strings"
+)
+
+// Nameserver types as returned in Ns.Type
+const (
+	NsTypeFree    = "free"
+	NsTypePremium = "premium"
+)
+
+// NsFilter selects nameservers from the result of Ns.List, zero fields match everything
+type NsFilter struct {
+	Type          string   // NsTypeFree or NsTypePremium
+	DdosProtected bool     // only DDoS protected servers
+	Countries     []string // country codes matched against Ns.LocationCc
+}
+
+// Match reports whether a nameserver passes the filter
+func (f NsFilter) Match(n Ns) bool {
+	if f.Type != "" && !strings.EqualFold(f.Type, n.Type) {
+		return false
+	}
+	if f.DdosProtected && n.DdosProtected == 0 {
+		return false
+	}
+	if len(f.Countries) > 0 && !slices.ContainsFunc(f.Countries, func(cc string) bool {
+		return strings.EqualFold(cc, n.LocationCc)
+	}) {
+		return false
+	}
+	return true
+}
+
+// Apply returns the nameservers that pass the filter
+func (f NsFilter) Apply(list []Ns) []Ns {
+	var rn []Ns
+	for _, n := range list {
+		if f.Match(n) {
+			rn = append(rn, n)
+		}
+	}
+	return rn
+}
+
+// NsNames returns the names of the nameservers, e.g. to use as Zone.Ns
+func NsNames(list []Ns) []string {
+	names := make([]string, 0, len(list))
+	for _, n := range list {
+		names = append(names, n.Name)
+	}
+	return names
+}
@@ -0,0 +1,47 @@
+package cloudns
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestNsListDetails(t *testing.T) {
+	newTestAPI(t, func(path string, body map[string]interface{}) interface{} {
+		if body["detailed-info"] != float64(1) {
+			t.Errorf("Expected detailed-info=1, got %v", body["detailed-info"])
+		}
+		return []map[string]interface{}{
+			{"type": "free", "name": "ns1.cloudns.net", "ip4": "185.136.96.79", "ip6": "2a06:fb00:1::1:79", "location": "Sofia", "location_cc": "BG", "ddos_protected": 0},
+			{"type": "premium", "name": "pns41.cloudns.net", "ip4": "185.136.96.41", "ip6": "2a06:fb00:1::1:41", "location": "Frankfurt", "location_cc": "DE", "ddos_protected": "1"},
+			{"type": "premium", "name": "pns42.cloudns.com", "ip4": "185.136.97.42", "ip6": "2a06:fb00:1::2:42", "location": "New York", "location_cc": "US", "ddos_protected": 1},
+		}
+	})
+
+	list, err := Ns{}.List(*testApiAccess)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(list) != 3 {
+		t.Fatalf("Expected 3 nameservers, got %d", len(list))
+	}
+	expected := Ns{
+		Type:          "premium",
+		Name:          "pns41.cloudns.net",
+		Ip4:           "185.136.96.41",
+		Ip6:           "2a06:fb00:1::1:41",
+		Location:      "Frankfurt",
+		LocationCc:    "DE",
+		DdosProtected: 1,
+	}
+	if list[1] != expected {
+		t.Errorf("Expected %+v, got %+v", expected, list[1])
+	}
+
+	f := NsFilter{Type: NsTypePremium, DdosProtected: true, Countries: []string{"de"}}
+	if names := NsNames(f.Apply(list)); !slices.Equal(names, []string{"pns41.cloudns.net"}) {
+		t.Errorf("Unexpected filter result %v", names)
+	}
+	if names := NsNames(NsFilter{}.Apply(list)); len(names) != 3 {
+		t.Errorf("Expected an empty filter to match everything, got %v", names)
+	}
+}
@@ -0,0 +1,113 @@
+// Package cloudns failover notifications
+package cloudns
+
+import (
+	"errors"
+
+	"github.com/tidwall/gjson"
+)
+
+// Failover notification types
+const (
+	NotificationMail    = "mail"
+	NotificationWebhook = "webhook"
+)
+
+// FailoverNotification is a target that is notified when the failover
+// check of a record changes state
+type FailoverNotification struct {
+	Domain   string `json:"domain-name"`
+	RecordId string `json:"record-id"`
+	ID       string `json:"id,omitempty"`
+	Type     string `json:"type"`
+	Value    string `json:"value"`
+}
+
+func newFailoverNotification(n FailoverNotification, a *Apiaccess) failovernotification {
+	return failovernotification{
+		Authid:         a.Authid,
+		Subauthid:      a.Subauthid,
+		Subauthuser:    a.Subauthuser,
+		Authpassword:   a.Authpassword,
+		Domain:         n.Domain,
+		RecordId:       n.RecordId,
+		NotificationId: n.ID,
+		Type:           n.Type,
+		Value:          n.Value,
+	}
+}
+
+// Notifications returns the notifications of the failover (max: 100)
+func (f Failover) Notifications(a *Apiaccess) ([]FailoverNotification, error) {
+	return FailoverNotification{Domain: f.Domain, RecordId: f.RecordId}.List(a)
+}
+
+// List returns all notifications of the failover record (max: 100)
+func (n FailoverNotification) List(a *Apiaccess) ([]FailoverNotification, error) {
+	var rn []FailoverNotification
+	inn := newFailoverNotification(n, a)
+	inn.NotificationId = ""
+	inn.Type = ""
+	inn.Value = ""
+	inn.Page = 1
+	inn.Hits = 100
+
+	resp, err := inn.list()
+	if err != nil {
+		return rn, err
+	}
+	errmsg, isapierr := checkapierr(resp.Body())
+	if isapierr {
+		return rn, errors.New(errmsg)
+	}
+	intrn, err := unmarshallist[retnotification](resp.Body())
+	for _, tmp := range intrn {
+		rn = append(rn, FailoverNotification{
+			Domain:   n.Domain,
+			RecordId: n.RecordId,
+			ID:       tmp.ID.String(),
+			Type:     tmp.Type,
+			Value:    tmp.Value,
+		})
+	}
+	return rn, err
+}
+
+// Add a notification, Type is NotificationMail or NotificationWebhook and
+// Value the mail address or the URL
+func (n FailoverNotification) Add(a *Apiaccess) (FailoverNotification, error) {
+	if n.Type == "" || n.Value == "" {
+		return n, errors.New("notification needs a type and a value")
+	}
+	inn := newFailoverNotification(n, a)
+	inn.NotificationId = ""
+
+	resp, err := inn.add()
+	if err == nil {
+		errmsg, isapierr := checkapierr(resp.Body())
+		if isapierr {
+			return n, errors.New(errmsg)
+		}
+		if newid := gjson.GetBytes(resp.Body(), "data.id"); newid.Exists() {
+			n.ID = newid.String()
+		}
+	}
+	return n, err
+}
+
+// Delete a notification by its ID
+func (n FailoverNotification) Delete(a *Apiaccess) (FailoverNotification, error) {
+	if n.ID == "" {
+		return n, errors.New("notification needs an id")
+	}
+	inn := newFailoverNotification(n, a)
+
+	resp, err := inn.destroy()
+	if err == nil {
+		errmsg, isapierr := checkapierr(resp.Body())
+		if isapierr {
+			return n, errors.New(errmsg)
+		}
+	}
+	return n, err
+}
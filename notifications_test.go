@@ -0,0 +1,51 @@
+package cloudns
+
+import (
+	"testing"
+)
+
+func TestFailoverNotifications(t *testing.T) {
+	var sent map[string]interface{}
+	newTestAPI(t, func(path string, body map[string]interface{}) interface{} {
+		sent = body
+		switch path {
+		case "/dns/failover-notifications-list.json":
+			return map[string]interface{}{
+				"11": map[string]interface{}{"id": 11, "type": "mail", "value": "ops@example.com"},
+				"12": map[string]interface{}{"id": "12", "type": "webhook", "value": "https://example.com/hook"},
+			}
+		case "/dns/failover-notifications-add.json":
+			return map[string]interface{}{"status": "Success", "data": map[string]int{"id": 13}}
+		case "/dns/failover-notifications-delete.json":
+			return map[string]string{"status": "Success"}
+		}
+		t.Errorf("Unexpected path %s", path)
+		return nil
+	})
+
+	f := Failover{Domain: "testzone.bg", RecordId: "518569025"}
+	list, err := f.Notifications(testApiAccess)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	expected := FailoverNotification{Domain: "testzone.bg", RecordId: "518569025", ID: "12", Type: NotificationWebhook, Value: "https://example.com/hook"}
+	if len(list) != 2 || list[1] != expected {
+		t.Errorf("Unexpected notifications %+v", list)
+	}
+
+	n := FailoverNotification{Domain: f.Domain, RecordId: f.RecordId, Type: NotificationMail, Value: "oncall@example.com"}
+	n, err = n.Add(testApiAccess)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if n.ID != "13" {
+		t.Errorf("Expected id 13, got %q", n.ID)
+	}
+
+	if _, err = n.Delete(testApiAccess); err != nil {
+		t.Errorf("Expected no error, got %v", err)
+	}
+	if sent["notification-id"] != "13" || sent["record-id"] != "518569025" {
+		t.Errorf("Unexpected delete request %v", sent)
+	}
+}
@@ -0,0 +1,104 @@
+// Package cloudns zone propagation status
+package cloudns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/tidwall/gjson"
+)
+
+// ZoneServerStatus tells whether one nameserver serves the latest version of a zone
+type ZoneServerStatus struct {
+	Server  string `json:"server"`
+	IP4     string `json:"ip4,omitempty"`
+	IP6     string `json:"ip6,omitempty"`
+	Updated bool   `json:"updated"`
+}
+
+func newZupdate(z Zone, a *Apiaccess) zupdate {
+	return zupdate{
+		Authid:       a.Authid,
+		Subauthid:    a.Subauthid,
+		Subauthuser:  a.Subauthuser,
+		Authpassword: a.Authpassword,
+		Domain:       z.Domain,
+	}
+}
+
+// IsUpdated reports whether all nameservers serve the latest version of the zone
+func (z Zone) IsUpdated(ctx context.Context, a *Apiaccess) (bool, error) {
+	resp, err := newZupdate(z, a).isupdated(ctx)
+	if err != nil {
+		return false, err
+	}
+	errmsg, isapierr := checkapierr(resp.Body())
+	if isapierr {
+		return false, errors.New(errmsg)
+	}
+	res := gjson.ParseBytes(resp.Body())
+	if res.Type != gjson.True && res.Type != gjson.False {
+		return false, fmt.Errorf("unexpected response %q", resp.Body())
+	}
+	return res.Bool(), nil
+}
+
+// UpdateStatus returns for every nameserver whether it serves the latest version of the zone
+func (z Zone) UpdateStatus(ctx context.Context, a *Apiaccess) ([]ZoneServerStatus, error) {
+	resp, err := newZupdate(z, a).updatestatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+	errmsg, isapierr := checkapierr(resp.Body())
+	if isapierr {
+		return nil, errors.New(errmsg)
+	}
+	list, err := unmarshallist[retupdatestatus](resp.Body())
+	if err != nil {
+		return nil, fmt.Errorf("error unmarshalling response: %v", err)
+	}
+	var rs []ZoneServerStatus
+	for _, s := range list {
+		rs = append(rs, ZoneServerStatus(s))
+	}
+	return rs, nil
+}
+
+// DefaultPollInterval is used by WaitPropagated for a pollInterval of zero or less
+const DefaultPollInterval = 5 * time.Second
+
+// WaitPropagated polls IsUpdated every pollInterval until all nameservers serve
+// the latest version of the zone, e.g. before an ACME server checks a TXT record.
+// Failed requests are retried, if the context ends first the context error is
+// returned together with the last request error.
+func (z Zone) WaitPropagated(ctx context.Context, a *Apiaccess, pollInterval time.Duration) error {
+	t := time.NewTicker(pollinterval(pollInterval))
+	defer t.Stop()
+	var lasterr error
+	for {
+		updated, err := z.IsUpdated(ctx, a)
+		if err == nil && updated {
+			return nil
+		}
+		if err != nil && ctx.Err() == nil {
+			lasterr = err
+		}
+		select {
+		case <-ctx.Done():
+			if lasterr != nil {
+				return fmt.Errorf("%w, last error: %w", ctx.Err(), lasterr)
+			}
+			return ctx.Err()
+		case <-t.C:
+		}
+	}
+}
+
+func pollinterval(d time.Duration) time.Duration {
+	if d <= 0 {
+		return DefaultPollInterval
+	}
+	return d
+}
@@ -0,0 +1,93 @@
+package cloudns
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestZoneUpdateStatus(t *testing.T) {
+	newTestAPI(t, func(path string, body map[string]interface{}) interface{} {
+		if body["domain-name"] != "testzone.bg" {
+			t.Errorf("Expected domain-name testzone.bg, got %v", body["domain-name"])
+		}
+		switch path {
+		case "/dns/is-updated.json":
+			return false
+		case "/dns/update-status.json":
+			return []map[string]interface{}{
+				{"server": "ns1.cloudns.net", "ip4": "185.136.96.66", "ip6": "2a06:fb00:1::1:66", "updated": true},
+				{"server": "ns2.cloudns.net", "ip4": "185.136.97.66", "updated": false},
+			}
+		}
+		t.Errorf("Unexpected path %s", path)
+		return nil
+	})
+
+	z := Zone{Domain: "testzone.bg"}
+	updated, err := z.IsUpdated(context.Background(), testApiAccess)
+	if err != nil || updated {
+		t.Errorf("Expected false, got %v %v", updated, err)
+	}
+	status, err := z.UpdateStatus(context.Background(), testApiAccess)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	want := []ZoneServerStatus{
+		{Server: "ns1.cloudns.net", IP4: "185.136.96.66", IP6: "2a06:fb00:1::1:66", Updated: true},
+		{Server: "ns2.cloudns.net", IP4: "185.136.97.66"},
+	}
+	if len(status) != len(want) || status[0] != want[0] || status[1] != want[1] {
+		t.Errorf("Expected %+v, got %+v", want, status)
+	}
+}
+
+func TestZoneWaitPropagated(t *testing.T) {
+	z := Zone{Domain: "testzone.bg"}
+
+	t.Run("retries failed requests", func(t *testing.T) {
+		var calls atomic.Int32
+		newTestAPI(t, func(path string, body map[string]interface{}) interface{} {
+			switch calls.Add(1) {
+			case 1:
+				return map[string]string{"status": "Failed", "statusDescription": "Temporary error."}
+			case 2:
+				return false
+			}
+			return true
+		})
+		if err := z.WaitPropagated(context.Background(), testApiAccess, time.Millisecond); err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+		if n := calls.Load(); n != 3 {
+			t.Errorf("Expected 3 calls, got %d", n)
+		}
+	})
+
+	t.Run("context ends", func(t *testing.T) {
+		newTestAPI(t, func(path string, body map[string]interface{}) interface{} {
+			return map[string]string{"status": "Failed", "statusDescription": "Temporary error."}
+		})
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		err := z.WaitPropagated(ctx, testApiAccess, time.Millisecond)
+		if !errors.Is(err, context.DeadlineExceeded) || !strings.Contains(err.Error(), "Temporary error.") {
+			t.Errorf("Expected context.DeadlineExceeded with the last error, got %v", err)
+		}
+	})
+
+	t.Run("zero interval", func(t *testing.T) {
+		if d := pollinterval(0); d != DefaultPollInterval {
+			t.Errorf("Expected %s, got %s", DefaultPollInterval, d)
+		}
+		newTestAPI(t, func(path string, body map[string]interface{}) interface{} {
+			return true
+		})
+		if err := z.WaitPropagated(context.Background(), testApiAccess, 0); err != nil {
+			t.Errorf("Expected no error, got %v", err)
+		}
+	})
+}
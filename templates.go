@@ -0,0 +1,201 @@
+// Package cloudns DNS templates
+package cloudns
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/tidwall/gjson"
+)
+
+// Template is a DNS template, a set of records that can be applied to zones,
+// e.g. the SPF, DMARC, MX and CAA records every new zone gets
+type Template struct {
+	ID   int    `json:"id,omitempty"`
+	Name string `json:"name"`
+}
+
+func newTemplate(t Template, a *Apiaccess) dnstemplate {
+	return dnstemplate{
+		Authid:       a.Authid,
+		Subauthid:    a.Subauthid,
+		Subauthuser:  a.Subauthuser,
+		Authpassword: a.Authpassword,
+		ID:           t.ID,
+		Name:         t.Name,
+	}
+}
+
+// List returns all DNS templates (max: 100)
+func (t Template) List(a *Apiaccess) ([]Template, error) {
+	var rt []Template
+	intt := newTemplate(Template{}, a)
+	intt.Page = 1
+	intt.Hits = 100
+
+	resp, err := intt.list()
+	if err != nil {
+		return rt, err
+	}
+	errmsg, isapierr := checkapierr(resp.Body())
+	if isapierr {
+		return rt, errors.New(errmsg)
+	}
+	intrt, err := unmarshallist[rettemplate](resp.Body())
+	for _, tmp := range intrt {
+		rt = append(rt, Template{ID: int(tmp.ID), Name: tmp.Name})
+	}
+	return rt, err
+}
+
+// Create a new, empty DNS template
+func (t Template) Create(a *Apiaccess) (Template, error) {
+	if t.Name == "" {
+		return t, errors.New("template needs a name")
+	}
+	intt := newTemplate(t, a)
+	intt.ID = 0
+
+	resp, err := intt.add()
+	if err == nil {
+		errmsg, isapierr := checkapierr(resp.Body())
+		if isapierr {
+			return t, errors.New(errmsg)
+		}
+		if newid := gjson.GetBytes(resp.Body(), "data.id"); newid.Exists() {
+			t.ID = int(newid.Int())
+		}
+	}
+	return t, err
+}
+
+// Update renames the DNS template
+func (t Template) Update(a *Apiaccess) (Template, error) {
+	if t.ID == 0 {
+		return t, errors.New("template needs an id")
+	}
+	resp, err := newTemplate(t, a).rename()
+	if err == nil {
+		errmsg, isapierr := checkapierr(resp.Body())
+		if isapierr {
+			return t, errors.New(errmsg)
+		}
+	}
+	return t, err
+}
+
+// Delete the DNS template, zones it was applied to keep their records
+func (t Template) Delete(a *Apiaccess) (Template, error) {
+	if t.ID == 0 {
+		return t, errors.New("template needs an id")
+	}
+	resp, err := newTemplate(Template{ID: t.ID}, a).destroy()
+	if err == nil {
+		errmsg, isapierr := checkapierr(resp.Body())
+		if isapierr {
+			return t, errors.New(errmsg)
+		}
+	}
+	return t, err
+}
+
+// Records returns the records of the DNS template, their Domain is empty
+func (t Template) Records(a *Apiaccess) ([]Record, error) {
+	var ra []Record
+	if t.ID == 0 {
+		return ra, errors.New("template needs an id")
+	}
+	resp, err := newTemplate(Template{ID: t.ID}, a).records()
+	if err != nil {
+		return ra, err
+	}
+	errmsg, isapierr := checkapierr(resp.Body())
+	if isapierr {
+		return ra, errors.New(errmsg)
+	}
+	var ratmp map[string]retrec
+	if err := json.Unmarshal(resp.Body(), &ratmp); err != nil {
+		return ra, fmt.Errorf("error unmarshalling response: %v", err)
+	}
+	for _, rec := range ratmp {
+		ra = append(ra, rec.record(""))
+	}
+	return ra, nil
+}
+
+// AddRecord adds the record to the DNS template, the Domain of the record is ignored
+func (t Template) AddRecord(a *Apiaccess, r Record) (Record, error) {
+	if t.ID == 0 {
+		return r, errors.New("template needs an id")
+	}
+	inr := newCreaterec(r, a)
+	inr.Domain = ""
+	inr.TemplateID = t.ID
+
+	resp, err := inr.createtemplate()
+	if err == nil {
+		errmsg, isapierr := checkapierr(resp.Body())
+		if isapierr {
+			return r, errors.New(errmsg)
+		}
+		r.ID = gjson.GetBytes(resp.Body(), "data.id").String()
+	}
+	return r, err
+}
+
+// UpdateRecord changes a record of the DNS template by its ID
+func (t Template) UpdateRecord(a *Apiaccess, r Record) (Record, error) {
+	if t.ID == 0 || r.ID == "" {
+		return r, errors.New("template and record need an id")
+	}
+	inr := newUpdaterec(r, a)
+	inr.Domain = ""
+	inr.TemplateID = t.ID
+
+	resp, err := inr.updatetemplate()
+	if err == nil {
+		errmsg, isapierr := checkapierr(resp.Body())
+		if isapierr {
+			return r, errors.New(errmsg)
+		}
+	}
+	return r, err
+}
+
+// DeleteRecord removes a record from the DNS template by its ID
+func (t Template) DeleteRecord(a *Apiaccess, r Record) (Record, error) {
+	if t.ID == 0 || r.ID == "" {
+		return r, errors.New("template and record need an id")
+	}
+	inr := newUpdaterec(Record{ID: r.ID}, a)
+	inr.TemplateID = t.ID
+
+	resp, err := inr.destroytemplate()
+	if err == nil {
+		errmsg, isapierr := checkapierr(resp.Body())
+		if isapierr {
+			return r, errors.New(errmsg)
+		}
+	}
+	return r, err
+}
+
+// ApplyTemplate adds the records of the DNS template to the zone,
+// set Zone.TemplateID to do it on Create
+func (z Zone) ApplyTemplate(a *Apiaccess, templateID int) (Zone, error) {
+	if templateID == 0 {
+		return z, errors.New("template needs an id")
+	}
+	intt := newTemplate(Template{ID: templateID}, a)
+	intt.Domain = z.Domain
+
+	resp, err := intt.apply()
+	if err == nil {
+		errmsg, isapierr := checkapierr(resp.Body())
+		if isapierr {
+			return z, errors.New(errmsg)
+		}
+	}
+	return z, err
+}
@@ -0,0 +1,93 @@
+package cloudns
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestTemplates(t *testing.T) {
+	var sent map[string]interface{}
+	var paths []string
+	newTestAPI(t, func(path string, body map[string]interface{}) interface{} {
+		sent = body
+		paths = append(paths, path)
+		switch path {
+		case "/dns/list-templates.json":
+			return map[string]interface{}{"4": map[string]string{"id": "4", "name": "baseline"}}
+		case "/dns/add-template.json", "/dns/add-template-record.json":
+			return map[string]interface{}{"status": "Success", "data": map[string]int{"id": 12}}
+		case "/dns/template-records.json":
+			return map[string]interface{}{"12": map[string]string{"id": "12", "type": "MX", "host": "", "record": "mx.example.com", "ttl": "3600", "priority": "10"}}
+		case "/dns/edit-template.json", "/dns/delete-template.json", "/dns/mod-template-record.json",
+			"/dns/delete-template-record.json", "/dns/apply-template.json", "/dns/register.json":
+			return map[string]string{"status": "Success"}
+		}
+		t.Errorf("Unexpected path %s", path)
+		return nil
+	})
+
+	templates, err := Template{}.List(testApiAccess)
+	if err != nil || len(templates) != 1 || templates[0] != (Template{ID: 4, Name: "baseline"}) {
+		t.Errorf("Unexpected templates %+v %v", templates, err)
+	}
+
+	tpl, err := Template{Name: "customers"}.Create(testApiAccess)
+	if err != nil || tpl.ID != 12 || sent["name"] != "customers" {
+		t.Errorf("Unexpected create %+v %v %v", tpl, err, sent)
+	}
+
+	r, err := tpl.AddRecord(testApiAccess, Record{Domain: "ignored.bg", Rtype: "MX", Record: "mx.example.com", TTL: 3600, Priority: 10})
+	if err != nil || r.ID != "12" || sent["template-id"] != 12.0 || sent["priority"] != 10.0 || sent["domain-name"] != nil {
+		t.Errorf("Unexpected add record %+v %v %v", r, err, sent)
+	}
+
+	records, err := tpl.Records(testApiAccess)
+	if err != nil || len(records) != 1 || records[0].Priority != 10 || records[0].Rtype != "MX" || records[0].TTL != 3600 {
+		t.Errorf("Unexpected records %+v %v", records, err)
+	}
+
+	r.Priority = 20
+	if _, err = tpl.UpdateRecord(testApiAccess, r); err != nil || sent["record-id"] != 12.0 || sent["priority"] != 20.0 {
+		t.Errorf("Unexpected update record %v %v", err, sent)
+	}
+	if _, err = tpl.DeleteRecord(testApiAccess, r); err != nil || sent["record-id"] != 12.0 || sent["template-id"] != 12.0 {
+		t.Errorf("Unexpected delete record %v %v", err, sent)
+	}
+
+	paths = nil
+	z, err := Zone{Domain: "testzone.bg", Ztype: "master", TemplateID: 12}.Create(testApiAccess)
+	if err != nil || len(paths) != 2 || paths[1] != "/dns/apply-template.json" || sent["domain-name"] != z.Domain || sent["template-id"] != 12.0 {
+		t.Errorf("Unexpected create with template %v %v %v", paths, err, sent)
+	}
+
+	if _, err = tpl.Delete(testApiAccess); err != nil || sent["template-id"] != 12.0 {
+		t.Errorf("Unexpected delete %v %v", err, sent)
+	}
+}
+
+func TestZoneCreateTemplateFailure(t *testing.T) {
+	var paths []string
+	newTestAPI(t, func(path string, body map[string]interface{}) interface{} {
+		paths = append(paths, path)
+		switch path {
+		case "/dns/register.json", "/dns/change-group.json":
+			return map[string]string{"status": "Success"}
+		case "/dns/list-groups.json":
+			return map[string]interface{}{"7": map[string]string{"id": "7", "name": "tenant-a"}}
+		case "/dns/apply-template.json":
+			return map[string]string{"status": "Failed", "statusDescription": "Invalid template-id."}
+		}
+		t.Errorf("Unexpected path %s", path)
+		return nil
+	})
+
+	z, err := Zone{Domain: "testzone.bg", Ztype: "master", TemplateID: 13, Group: "tenant-a"}.Create(testApiAccess)
+	if !errors.Is(err, ErrZoneSetup) || !strings.Contains(err.Error(), "Invalid template-id.") {
+		t.Errorf("Expected ErrZoneSetup, got %v", err)
+	}
+	// the group move is still done
+	if len(paths) != 4 || paths[3] != "/dns/change-group.json" || z.Group != "tenant-a" {
+		t.Errorf("Unexpected calls %v for %+v", paths, z)
+	}
+}
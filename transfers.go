@@ -0,0 +1,133 @@
+// Package cloudns zone transfer allow-list and notify targets
+package cloudns
+
+import (
+	"errors"
+	"net"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/tidwall/gjson"
+)
+
+// AxfrIP is an IP address allowed to transfer a master zone, e.g. a secondary DNS provider
+type AxfrIP struct {
+	Domain string `json:"domain-name"`
+	ID     string `json:"id,omitempty"`
+	IP     string `json:"ip"`
+}
+
+// NotifyTarget is a server that gets a NOTIFY when a master zone changes
+type NotifyTarget struct {
+	Domain string `json:"domain-name"`
+	ID     string `json:"id,omitempty"`
+	IP     string `json:"ip"`
+}
+
+func newZoneIP(domain string, id string, ip string, a *Apiaccess) zoneip {
+	return zoneip{
+		Authid:       a.Authid,
+		Subauthid:    a.Subauthid,
+		Subauthuser:  a.Subauthuser,
+		Authpassword: a.Authpassword,
+		Domain:       domain,
+		ID:           id,
+		IP:           ip,
+	}
+}
+
+// AxfrIPs returns the IP addresses allowed to transfer the zone
+func (z Zone) AxfrIPs(a *Apiaccess) ([]AxfrIP, error) {
+	return AxfrIP{Domain: z.Domain}.List(a)
+}
+
+// NotifyTargets returns the servers notified about changes of the zone
+func (z Zone) NotifyTargets(a *Apiaccess) ([]NotifyTarget, error) {
+	return NotifyTarget{Domain: z.Domain}.List(a)
+}
+
+// List returns all IP addresses allowed to transfer the zone
+func (x AxfrIP) List(a *Apiaccess) ([]AxfrIP, error) {
+	var rx []AxfrIP
+	intrx, err := listzoneips(newZoneIP(x.Domain, "", "", a).axfrlist)
+	for _, tmp := range intrx {
+		rx = append(rx, AxfrIP{Domain: x.Domain, ID: tmp.ID.String(), IP: tmp.Server})
+	}
+	return rx, err
+}
+
+// Add allows the IP address to transfer the zone
+func (x AxfrIP) Add(a *Apiaccess) (AxfrIP, error) {
+	if net.ParseIP(x.IP) == nil {
+		return x, errors.New("axfr needs a valid ip")
+	}
+	id, err := changezoneip(newZoneIP(x.Domain, "", x.IP, a).axfradd)
+	if id != "" {
+		x.ID = id
+	}
+	return x, err
+}
+
+// Delete removes the IP address from the allow-list by its ID
+func (x AxfrIP) Delete(a *Apiaccess) (AxfrIP, error) {
+	if x.ID == "" {
+		return x, errors.New("axfr ip needs an id")
+	}
+	_, err := changezoneip(newZoneIP(x.Domain, x.ID, "", a).axfrremove)
+	return x, err
+}
+
+// List returns all servers notified about changes of the zone
+func (n NotifyTarget) List(a *Apiaccess) ([]NotifyTarget, error) {
+	var rn []NotifyTarget
+	intrn, err := listzoneips(newZoneIP(n.Domain, "", "", a).notifylist)
+	for _, tmp := range intrn {
+		rn = append(rn, NotifyTarget{Domain: n.Domain, ID: tmp.ID.String(), IP: tmp.Server})
+	}
+	return rn, err
+}
+
+// Add a server to notify about changes of the zone
+func (n NotifyTarget) Add(a *Apiaccess) (NotifyTarget, error) {
+	if net.ParseIP(n.IP) == nil {
+		return n, errors.New("notify target needs a valid ip")
+	}
+	id, err := changezoneip(newZoneIP(n.Domain, "", n.IP, a).notifyadd)
+	if id != "" {
+		n.ID = id
+	}
+	return n, err
+}
+
+// Delete a notify target by its ID
+func (n NotifyTarget) Delete(a *Apiaccess) (NotifyTarget, error) {
+	if n.ID == "" {
+		return n, errors.New("notify target needs an id")
+	}
+	_, err := changezoneip(newZoneIP(n.Domain, n.ID, "", a).notifydelete)
+	return n, err
+}
+
+func listzoneips(call func() (*resty.Response, error)) ([]retzoneip, error) {
+	resp, err := call()
+	if err != nil {
+		return nil, err
+	}
+	errmsg, isapierr := checkapierr(resp.Body())
+	if isapierr {
+		return nil, errors.New(errmsg)
+	}
+	return unmarshallist[retzoneip](resp.Body())
+}
+
+// changezoneip runs an add or delete call and returns the id of an added entry
+func changezoneip(call func() (*resty.Response, error)) (string, error) {
+	resp, err := call()
+	if err != nil {
+		return "", err
+	}
+	errmsg, isapierr := checkapierr(resp.Body())
+	if isapierr {
+		return "", errors.New(errmsg)
+	}
+	return gjson.GetBytes(resp.Body(), "data.id").String(), nil
+}
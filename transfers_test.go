@@ -0,0 +1,45 @@
+package cloudns
+
+import (
+	"testing"
+)
+
+func TestZoneTransfers(t *testing.T) {
+	var sent map[string]interface{}
+	newTestAPI(t, func(path string, body map[string]interface{}) interface{} {
+		sent = body
+		switch path {
+		case "/dns/axfr-list.json":
+			return map[string]interface{}{"3": map[string]interface{}{"id": "3", "server": "192.0.2.53"}}
+		case "/dns/notify-list.json":
+			return []map[string]interface{}{{"id": 5, "server": "2001:db8::53"}}
+		case "/dns/axfr-add.json", "/dns/notify-add.json":
+			return map[string]interface{}{"status": "Success", "data": map[string]int{"id": 9}}
+		case "/dns/axfr-remove.json", "/dns/notify-delete.json":
+			return map[string]string{"status": "Success"}
+		}
+		t.Errorf("Unexpected path %s", path)
+		return nil
+	})
+
+	z := Zone{Domain: "testzone.bg", Ztype: "master"}
+	axfr, err := z.AxfrIPs(testApiAccess)
+	if err != nil || len(axfr) != 1 || axfr[0] != (AxfrIP{Domain: "testzone.bg", ID: "3", IP: "192.0.2.53"}) {
+		t.Errorf("Unexpected axfr list %+v %v", axfr, err)
+	}
+	notify, err := z.NotifyTargets(testApiAccess)
+	if err != nil || len(notify) != 1 || notify[0] != (NotifyTarget{Domain: "testzone.bg", ID: "5", IP: "2001:db8::53"}) {
+		t.Errorf("Unexpected notify list %+v %v", notify, err)
+	}
+
+	x, err := AxfrIP{Domain: z.Domain, IP: "198.51.100.53"}.Add(testApiAccess)
+	if err != nil || x.ID != "9" || sent["ip"] != "198.51.100.53" {
+		t.Errorf("Unexpected add %+v %v %v", x, err, sent)
+	}
+	if _, err = x.Delete(testApiAccess); err != nil || sent["id"] != "9" {
+		t.Errorf("Unexpected delete %v %v", err, sent)
+	}
+	if _, err = (NotifyTarget{Domain: z.Domain}).Delete(testApiAccess); err == nil {
+		t.Errorf("Expected an error without id")
+	}
+}
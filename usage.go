@@ -0,0 +1,64 @@
+// Package cloudns account usage and limits
+package cloudns
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Usage is the number of zones on the account and the limit of the plan
+type Usage struct {
+	Zones     int `json:"zones"`
+	ZoneLimit int `json:"zone_limit"`
+}
+
+// ZonesLeft returns how many zones can still be created
+func (u Usage) ZonesLeft() int {
+	return max(u.ZoneLimit-u.Zones, 0)
+}
+
+// RecordUsage is the number of records in a zone and the limit of the plan
+type RecordUsage struct {
+	Domain      string `json:"domain-name"`
+	Records     int    `json:"records"`
+	RecordLimit int    `json:"record_limit"`
+}
+
+// RecordsLeft returns how many records can still be added to the zone
+func (u RecordUsage) RecordsLeft() int {
+	return max(u.RecordLimit-u.Records, 0)
+}
+
+// Usage returns the zone count and zone limit of the account, check it before Zone.Create
+func (a Apiaccess) Usage(ctx context.Context) (Usage, error) {
+	resp, err := a.zonesstats(ctx)
+	if err != nil {
+		return Usage{}, err
+	}
+	ru, err := readusage(resp.Body())
+	return Usage{Zones: int(ru.Count), ZoneLimit: int(ru.Limit)}, err
+}
+
+// RecordUsage returns the record count and record limit of the zone
+func (z Zone) RecordUsage(ctx context.Context, a *Apiaccess) (RecordUsage, error) {
+	resp, err := newZupdate(z, a).recordsstats(ctx)
+	if err != nil {
+		return RecordUsage{Domain: z.Domain}, err
+	}
+	ru, err := readusage(resp.Body())
+	return RecordUsage{Domain: z.Domain, Records: int(ru.Count), RecordLimit: int(ru.Limit)}, err
+}
+
+func readusage(body []byte) (retusage, error) {
+	var ru retusage
+	errmsg, isapierr := checkapierr(body)
+	if isapierr {
+		return ru, errors.New(errmsg)
+	}
+	if err := json.Unmarshal(body, &ru); err != nil {
+		return ru, fmt.Errorf("error unmarshalling response: %v", err)
+	}
+	return ru, nil
+}
@@ -0,0 +1,48 @@
+package cloudns
+
+import (
+	"context"
+	"testing"
+)
+
+func TestUsage(t *testing.T) {
+	newTestAPI(t, func(path string, body map[string]interface{}) interface{} {
+		switch path {
+		case "/dns/get-zones-stats.json":
+			return map[string]string{"count": "98", "limit": "100"}
+		case "/dns/get-records-stats.json":
+			if body["domain-name"] != "testzone.bg" {
+				t.Errorf("Expected domain-name testzone.bg, got %v", body["domain-name"])
+			}
+			return map[string]int{"count": 60, "limit": 50}
+		}
+		t.Errorf("Unexpected path %s", path)
+		return nil
+	})
+
+	u, err := testApiAccess.Usage(context.Background())
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if u != (Usage{Zones: 98, ZoneLimit: 100}) || u.ZonesLeft() != 2 {
+		t.Errorf("Unexpected usage %+v", u)
+	}
+
+	ru, err := Zone{Domain: "testzone.bg"}.RecordUsage(context.Background(), testApiAccess)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if ru != (RecordUsage{Domain: "testzone.bg", Records: 60, RecordLimit: 50}) || ru.RecordsLeft() != 0 {
+		t.Errorf("Unexpected record usage %+v", ru)
+	}
+}
+
+func TestUsageError(t *testing.T) {
+	newTestAPI(t, func(path string, body map[string]interface{}) interface{} {
+		return map[string]string{"status": "Failed", "statusDescription": "Invalid authentication, incorrect auth-id or auth-password."}
+	})
+
+	if _, err := testApiAccess.Usage(context.Background()); err == nil {
+		t.Errorf("Expected an error")
+	}
+}
@@ -0,0 +1,122 @@
+// Package webhook receives the failover callbacks ClouDNS sends when a check goes up or down
+package webhook
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	cloudns "github.com/ClouDNS/cloudns-go"
+)
+
+// FailoverEvent is one state change of a failover check
+type FailoverEvent struct {
+	Domain   string                // zone of the record
+	RecordId string                // id of the record with the failover
+	Host     string                // host of the record
+	State    cloudns.FailoverState // state after the change
+	IP       string                // IP the record serves after the change
+	Time     time.Time             // when ClouDNS saw the change, the receive time if not sent
+	Raw      url.Values            // all parameters of the callback except the secret
+}
+
+// Callback parameters read by ParseFailoverEvent
+const (
+	ParamSecret   = "secret"
+	ParamDomain   = "domain-name"
+	ParamRecordId = "record-id"
+	ParamHost     = "host"
+	ParamStatus   = "status"
+	ParamIP       = "ip"
+	ParamTime     = "time"
+)
+
+// Handler is an http.Handler for the failover webhook URL, events go to OnEvent
+// and to Events, whichever is set
+type Handler struct {
+	// Secret, if set, has to match the secret parameter of the callback URL,
+	// e.g. https://example.com/cloudns?secret=..., so others can not fake events
+	Secret string
+	// OnEvent is called for every event before the response is written
+	OnEvent func(FailoverEvent)
+	// Events receives every event, the callback waits until it is read
+	Events chan<- FailoverEvent
+}
+
+// NewHandler returns a handler calling fn for every event
+func NewHandler(secret string, fn func(FailoverEvent)) *Handler {
+	return &Handler{Secret: secret, OnEvent: fn}
+}
+
+// NewChannelHandler returns a handler and the channel its events are delivered on
+func NewChannelHandler(secret string, buffer int) (*Handler, <-chan FailoverEvent) {
+	ch := make(chan FailoverEvent, buffer)
+	return &Handler{Secret: secret, Events: ch}, ch
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		w.Header().Set("Allow", "GET, POST")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if h.Secret != "" && subtle.ConstantTimeCompare([]byte(r.Form.Get(ParamSecret)), []byte(h.Secret)) != 1 {
+		http.Error(w, "invalid secret", http.StatusForbidden)
+		return
+	}
+	ev, err := ParseFailoverEvent(r.Form)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if h.OnEvent != nil {
+		h.OnEvent(ev)
+	}
+	if h.Events != nil {
+		select {
+		case h.Events <- ev:
+		case <-r.Context().Done():
+			http.Error(w, "event not delivered", http.StatusServiceUnavailable)
+			return
+		}
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ParseFailoverEvent reads an event from the callback parameters (query string or form),
+// the secret is dropped from Raw so it does not reach the event consumers
+func ParseFailoverEvent(v url.Values) (FailoverEvent, error) {
+	raw := make(url.Values, len(v))
+	for k, vals := range v {
+		if k != ParamSecret {
+			raw[k] = vals
+		}
+	}
+	ev := FailoverEvent{
+		Domain:   strings.TrimSpace(v.Get(ParamDomain)),
+		RecordId: strings.TrimSpace(v.Get(ParamRecordId)),
+		Host:     strings.TrimSpace(v.Get(ParamHost)),
+		State:    cloudns.ParseFailoverState(strings.TrimSpace(v.Get(ParamStatus))),
+		IP:       strings.TrimSpace(v.Get(ParamIP)),
+		Time:     cloudns.ParseAPITime(strings.TrimSpace(v.Get(ParamTime))),
+		Raw:      raw,
+	}
+	if ev.Domain == "" || ev.RecordId == "" {
+		return ev, errors.New("callback without domain-name and record-id")
+	}
+	if ev.State == cloudns.FailoverUnknown {
+		return ev, errors.New("callback without up or down status")
+	}
+	if ev.Time.IsZero() {
+		ev.Time = time.Now().UTC()
+	}
+	return ev, nil
+}
@@ -0,0 +1,75 @@
+package webhook
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	cloudns "github.com/ClouDNS/cloudns-go"
+)
+
+func TestChannelHandler(t *testing.T) {
+	h, events := NewChannelHandler("s3cret", 1)
+
+	req := httptest.NewRequest(http.MethodGet, "/cloudns?secret=s3cret&domain-name=testzone.bg&record-id=518569025&host=www&status=DOWN&ip=192.168.0.5&time=2026-10-01+08:15:00", nil)
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("Expected 204, got %d %s", rec.Code, rec.Body)
+	}
+
+	ev := <-events
+	if ev.Domain != "testzone.bg" || ev.RecordId != "518569025" || ev.Host != "www" || ev.State != cloudns.FailoverDown || ev.IP != "192.168.0.5" {
+		t.Errorf("Unexpected event %+v", ev)
+	}
+	if !ev.Time.Equal(time.Date(2026, 10, 1, 8, 15, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected time %v", ev.Time)
+	}
+	if _, ok := ev.Raw["secret"]; ok || ev.Raw.Get("host") != "www" {
+		t.Errorf("Unexpected raw parameters %v", ev.Raw)
+	}
+}
+
+func TestHandlerPostForm(t *testing.T) {
+	var got []FailoverEvent
+	h := NewHandler("", func(ev FailoverEvent) { got = append(got, ev) })
+
+	form := url.Values{"domain-name": {"testzone.bg"}, "record-id": {"518569025"}, "status": {"1"}, "ip": {"192.168.0.1"}}
+	req := httptest.NewRequest(http.MethodPost, "/cloudns", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("Expected 204, got %d %s", rec.Code, rec.Body)
+	}
+	if len(got) != 1 || got[0].State != cloudns.FailoverUp || got[0].Time.IsZero() {
+		t.Errorf("Unexpected events %+v", got)
+	}
+}
+
+func TestHandlerRejects(t *testing.T) {
+	h := NewHandler("s3cret", func(ev FailoverEvent) { t.Errorf("Unexpected event %+v", ev) })
+
+	tests := []struct {
+		name   string
+		target string
+		code   int
+	}{
+		{"wrong secret", "/cloudns?secret=nope&domain-name=testzone.bg&record-id=1&status=up", http.StatusForbidden},
+		{"no state", "/cloudns?secret=s3cret&domain-name=testzone.bg&record-id=1", http.StatusBadRequest},
+		{"no record", "/cloudns?secret=s3cret&domain-name=testzone.bg&status=up", http.StatusBadRequest},
+		{"undocumented parameter names", "/cloudns?secret=s3cret&zone=testzone.bg&id=1&status=up", http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.target, nil))
+			if rec.Code != tt.code {
+				t.Errorf("Expected %d, got %d", tt.code, rec.Code)
+			}
+		})
+	}
+}
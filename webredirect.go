@@ -0,0 +1,161 @@
+// Package cloudns web redirects
+package cloudns
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+// RedirectType is the HTTP status code a web redirect answers with
+type RedirectType int
+
+// RedirectType values
+const (
+	RedirectPermanent RedirectType = 301
+	RedirectTemporary RedirectType = 302
+)
+
+// WebRedirect is a WR record, the ClouDNS web servers redirect requests for
+// Host.Domain to Target or show Target in a frame
+type WebRedirect struct {
+	Domain       string       `json:"domain-name"`
+	ID           string       `json:"id,omitempty"`
+	Host         string       `json:"host"`
+	Target       string       `json:"record"`
+	TTL          int          `json:"ttl"`
+	RedirectType RedirectType `json:"redirect-type,omitempty"` // RedirectPermanent if not set, ignored for frames
+	// SavePath appends the requested path to Target, /foo on the host goes to Target/foo
+	SavePath bool `json:"save-path"`
+	// Frame shows Target in a frame, the address bar keeps the redirected name
+	Frame            bool   `json:"frame"`
+	FrameTitle       string `json:"frame-title,omitempty"`
+	FrameKeywords    string `json:"frame-keywords,omitempty"`
+	FrameDescription string `json:"frame-description,omitempty"`
+	MobileMeta       bool   `json:"mobile-meta"`
+}
+
+// Validate checks the target and the redirect type
+func (w WebRedirect) Validate() error {
+	u, err := url.Parse(w.Target)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("web redirect needs an http or https target, got %q", w.Target)
+	}
+	if !w.Frame && w.RedirectType != 0 && w.RedirectType != RedirectPermanent && w.RedirectType != RedirectTemporary {
+		return fmt.Errorf("unsupported redirect type %d, use 301 or 302", w.RedirectType)
+	}
+	return nil
+}
+
+// record maps the web redirect onto a WR record
+func (w WebRedirect) record() Record {
+	r := Record{
+		Domain:           w.Domain,
+		ID:               w.ID,
+		Host:             w.Host,
+		Rtype:            "WR",
+		TTL:              w.TTL,
+		Record:           w.Target,
+		Frame:            "0",
+		FrameTitle:       w.FrameTitle,
+		FrameKeywords:    w.FrameKeywords,
+		FrameDescription: w.FrameDescription,
+	}
+	if w.Frame {
+		r.Frame = "1"
+	} else {
+		r.RedirectType = int(w.RedirectType)
+		if r.RedirectType == 0 {
+			r.RedirectType = int(RedirectPermanent)
+		}
+	}
+	if w.SavePath {
+		r.SavePath = 1
+	}
+	if w.MobileMeta {
+		r.MobileMeta = 1
+	}
+	return r
+}
+
+func webredirect(r Record) WebRedirect {
+	return WebRedirect{
+		Domain:           r.Domain,
+		ID:               r.ID,
+		Host:             r.Host,
+		Target:           r.Record,
+		TTL:              r.TTL,
+		RedirectType:     RedirectType(r.RedirectType),
+		SavePath:         r.SavePath == 1,
+		Frame:            r.Frame == "1",
+		FrameTitle:       r.FrameTitle,
+		FrameKeywords:    r.FrameKeywords,
+		FrameDescription: r.FrameDescription,
+		MobileMeta:       r.MobileMeta == 1,
+	}
+}
+
+// WebRedirects returns the web redirects of the zone
+func (z Zone) WebRedirects(a *Apiaccess) ([]WebRedirect, error) {
+	return WebRedirect{Domain: z.Domain}.List(a)
+}
+
+// List returns all web redirects of the zone
+func (w WebRedirect) List(a *Apiaccess) ([]WebRedirect, error) {
+	var rw []WebRedirect
+	records, err := Zone{Domain: w.Domain}.List(a)
+	for _, r := range records {
+		if r.Rtype == "WR" {
+			rw = append(rw, webredirect(r))
+		}
+	}
+	return rw, err
+}
+
+// Create a new web redirect
+func (w WebRedirect) Create(a *Apiaccess) (WebRedirect, error) {
+	if err := w.Validate(); err != nil {
+		return w, err
+	}
+	r, err := w.record().Create(a)
+	w.ID = r.ID
+	return w, err
+}
+
+// Read a web redirect by its ID
+func (w WebRedirect) Read(a *Apiaccess) (WebRedirect, error) {
+	if w.ID == "" {
+		return w, errors.New("web redirect needs an id")
+	}
+	all, err := w.List(a)
+	if err != nil {
+		return w, err
+	}
+	for _, tmp := range all {
+		if tmp.ID == w.ID {
+			return tmp, nil
+		}
+	}
+	return w, fmt.Errorf("web redirect %s: %w", w.ID, ErrRecordNotFound)
+}
+
+// Update a web redirect by its ID
+func (w WebRedirect) Update(a *Apiaccess) (WebRedirect, error) {
+	if w.ID == "" {
+		return w, errors.New("web redirect needs an id")
+	}
+	if err := w.Validate(); err != nil {
+		return w, err
+	}
+	_, err := w.record().Update(a)
+	return w, err
+}
+
+// Delete a web redirect by its ID
+func (w WebRedirect) Delete(a *Apiaccess) (WebRedirect, error) {
+	if w.ID == "" {
+		return w, errors.New("web redirect needs an id")
+	}
+	_, err := w.record().Destroy(a)
+	return w, err
+}
@@ -0,0 +1,96 @@
+package cloudns
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestWebRedirects(t *testing.T) {
+	resetAvailCache(t)
+
+	var sent map[string]interface{}
+	newTestAPI(t, func(path string, body map[string]interface{}) interface{} {
+		switch path {
+		case "/dns/get-zone-info.json":
+			return zoneInfoResponse(body)
+		case "/dns/get-available-ttl.json":
+			return []int{300, 3600}
+		case "/dns/get-available-record-types.json":
+			return []string{"A", "CNAME", "WR"}
+		case "/dns/records.json":
+			return map[string]interface{}{
+				"3": map[string]string{"id": "3", "type": "A", "host": "", "record": "192.0.2.1", "ttl": "3600"},
+				"4": map[string]string{"id": "4", "type": "WR", "host": "www", "record": "https://example.com", "ttl": "3600",
+					"redirect-type": "302", "save-path": "1", "frame": "0", "mobile-meta": "0"},
+				"5": map[string]string{"id": "5", "type": "WR", "host": "shop", "record": "https://shop.example.com", "ttl": "300",
+					"frame": "1", "frame-title": "Shop", "mobile-meta": "1", "save-path": "0"},
+			}
+		case "/dns/add-record.json":
+			sent = body
+			return map[string]interface{}{"status": "Success", "data": map[string]int{"id": 6}}
+		case "/dns/mod-record.json", "/dns/delete-record.json":
+			sent = body
+			return map[string]string{"status": "Success"}
+		}
+		t.Errorf("Unexpected path %s", path)
+		return nil
+	})
+
+	redirects, err := Zone{Domain: "testzone.bg"}.WebRedirects(testApiAccess)
+	if err != nil || len(redirects) != 2 {
+		t.Fatalf("Unexpected web redirects %+v %v", redirects, err)
+	}
+
+	w, err := WebRedirect{Domain: "testzone.bg", ID: "5"}.Read(testApiAccess)
+	want := WebRedirect{Domain: "testzone.bg", ID: "5", Host: "shop", Target: "https://shop.example.com", TTL: 300, Frame: true, FrameTitle: "Shop", MobileMeta: true}
+	if err != nil || w != want {
+		t.Errorf("Expected %+v, got %+v %v", want, w, err)
+	}
+	w, err = WebRedirect{Domain: "testzone.bg", ID: "4"}.Read(testApiAccess)
+	if err != nil || w.RedirectType != RedirectTemporary || !w.SavePath || w.Frame {
+		t.Errorf("Unexpected redirect %+v %v", w, err)
+	}
+	if _, err = (WebRedirect{Domain: "testzone.bg", ID: "3"}).Read(testApiAccess); !errors.Is(err, ErrRecordNotFound) {
+		t.Errorf("Expected ErrRecordNotFound, got %v", err)
+	}
+
+	w, err = WebRedirect{Domain: "testzone.bg", Host: "old", Target: "https://new.example.com", TTL: 3600, SavePath: true}.Create(testApiAccess)
+	if err != nil || w.ID != "6" {
+		t.Fatalf("Unexpected create %+v %v", w, err)
+	}
+	if sent["record-type"] != "WR" || sent["redirect-type"] != 301.0 || sent["save-path"] != 1.0 || sent["frame"] != "0" || sent["mobile-meta"] != 0.0 {
+		t.Errorf("Unexpected create request %v", sent)
+	}
+
+	w.SavePath = false
+	w.RedirectType = RedirectTemporary
+	if _, err = w.Update(testApiAccess); err != nil || sent["record-id"] != 6.0 || sent["save-path"] != 0.0 || sent["redirect-type"] != 302.0 {
+		t.Errorf("Unexpected update %v %v", err, sent)
+	}
+
+	if _, err = w.Delete(testApiAccess); err != nil || sent["record-id"] != 6.0 {
+		t.Errorf("Unexpected delete %v %v", err, sent)
+	}
+}
+
+func TestWebRedirectValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		w       WebRedirect
+		wantErr bool
+	}{
+		{"redirect", WebRedirect{Target: "https://example.com", RedirectType: RedirectTemporary}, false},
+		{"default type", WebRedirect{Target: "http://example.com/path"}, false},
+		{"frame", WebRedirect{Target: "https://example.com", Frame: true, RedirectType: 307}, false},
+		{"no scheme", WebRedirect{Target: "example.com"}, true},
+		{"ftp", WebRedirect{Target: "ftp://example.com"}, true},
+		{"bad type", WebRedirect{Target: "https://example.com", RedirectType: 307}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.w.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
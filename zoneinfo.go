@@ -0,0 +1,127 @@
+// Package cloudns zone information and query statistics
+package cloudns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/tidwall/gjson"
+)
+
+// ZoneInfo holds the details of a zone returned by get-zone-info
+type ZoneInfo struct {
+	Domain      string
+	Ztype       string
+	Kind        string // domain, ipv4 arpa, ipv6 arpa or parked
+	Active      bool
+	Group       string
+	CloudDomain bool
+}
+
+// StatsPeriod is the resolution of zone query statistics
+type StatsPeriod int
+
+// StatsPeriod values
+const (
+	StatsHourly  StatsPeriod = iota + 1 // the hours of the day of the given time
+	StatsDaily                          // the days of the month of the given time
+	StatsMonthly                        // the months of the year of the given time
+	StatsYearly                         // all years
+)
+
+// QueryStats is the number of queries to a zone in the period starting at Time
+type QueryStats struct {
+	Time    time.Time `json:"time"`
+	Queries int64     `json:"queries"`
+}
+
+// Info returns status, type, group and cloud domain flag of the zone
+func (z Zone) Info(ctx context.Context, a *Apiaccess) (ZoneInfo, error) {
+	ri := ZoneInfo{Domain: z.Domain}
+	resp, err := newZupdate(z, a).info(ctx)
+	if err != nil {
+		return ri, err
+	}
+	// the zone status shares the field with the API status, checkapierr would fail on it
+	res := gjson.ParseBytes(resp.Body())
+	if res.Get("status").String() == "Failed" {
+		return ri, errors.New(res.Get("statusDescription").String())
+	}
+	if !res.IsObject() {
+		return ri, fmt.Errorf("unexpected response %q", resp.Body())
+	}
+	ri.Ztype = res.Get("type").String()
+	ri.Kind = res.Get("zone").String()
+	ri.Active = res.Get("status").Int() == 1
+	ri.Group = res.Get("group").String()
+	ri.CloudDomain = res.Get("cloud-domain").Bool() || res.Get("cloud-domain").Int() == 1
+	return ri, nil
+}
+
+// Statistics returns the number of queries to the zone per hour, day, month or year,
+// at selects the day, month or year the statistics are for
+func (z Zone) Statistics(ctx context.Context, a *Apiaccess, period StatsPeriod, at time.Time) ([]QueryStats, error) {
+	at = at.UTC()
+	st := zonestats{
+		Authid:       a.Authid,
+		Subauthid:    a.Subauthid,
+		Subauthuser:  a.Subauthuser,
+		Authpassword: a.Authpassword,
+		Domain:       z.Domain,
+	}
+	var call func(context.Context) (*resty.Response, error)
+	var start func(n int) time.Time
+	switch period {
+	case StatsHourly:
+		st.Year, st.Month, st.Day = at.Year(), int(at.Month()), at.Day()
+		call = st.hourly
+		start = func(n int) time.Time { return time.Date(st.Year, time.Month(st.Month), st.Day, n, 0, 0, 0, time.UTC) }
+	case StatsDaily:
+		st.Year, st.Month = at.Year(), int(at.Month())
+		call = st.daily
+		start = func(n int) time.Time { return time.Date(st.Year, time.Month(st.Month), n, 0, 0, 0, 0, time.UTC) }
+	case StatsMonthly:
+		st.Year = at.Year()
+		call = st.monthly
+		start = func(n int) time.Time { return time.Date(st.Year, time.Month(n), 1, 0, 0, 0, 0, time.UTC) }
+	case StatsYearly:
+		call = st.yearly
+		start = func(n int) time.Time { return time.Date(n, 1, 1, 0, 0, 0, 0, time.UTC) }
+	default:
+		return nil, fmt.Errorf("unknown statistics period %d", period)
+	}
+
+	resp, err := call(ctx)
+	if err != nil {
+		return nil, err
+	}
+	errmsg, isapierr := checkapierr(resp.Body())
+	if isapierr {
+		return nil, errors.New(errmsg)
+	}
+	res := gjson.ParseBytes(resp.Body())
+	if !res.IsObject() {
+		return nil, fmt.Errorf("unexpected response %q", resp.Body())
+	}
+	var rs []QueryStats
+	var perr error
+	res.ForEach(func(key, value gjson.Result) bool {
+		n, err := strconv.Atoi(key.String())
+		if err != nil {
+			perr = fmt.Errorf("unexpected statistics key %q", key.String())
+			return false
+		}
+		rs = append(rs, QueryStats{Time: start(n), Queries: value.Int()})
+		return true
+	})
+	if perr != nil {
+		return nil, perr
+	}
+	sort.Slice(rs, func(i, j int) bool { return rs[i].Time.Before(rs[j].Time) })
+	return rs, nil
+}
@@ -0,0 +1,70 @@
+package cloudns
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestZoneInfo(t *testing.T) {
+	newTestAPI(t, func(path string, body map[string]interface{}) interface{} {
+		if path != "/dns/get-zone-info.json" {
+			t.Errorf("Unexpected path %s", path)
+		}
+		return map[string]interface{}{"name": "testzone.bg", "type": "master", "zone": "domain", "status": "1", "group": "customers", "cloud-domain": 1}
+	})
+
+	info, err := Zone{Domain: "testzone.bg"}.Info(context.Background(), testApiAccess)
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	want := ZoneInfo{Domain: "testzone.bg", Ztype: "master", Kind: "domain", Active: true, Group: "customers", CloudDomain: true}
+	if info != want {
+		t.Errorf("Expected %+v, got %+v", want, info)
+	}
+}
+
+func TestZoneStatistics(t *testing.T) {
+	var sent map[string]interface{}
+	var sentpath string
+	newTestAPI(t, func(path string, body map[string]interface{}) interface{} {
+		sent, sentpath = body, path
+		return map[string]interface{}{"10": "7", "2": 5, "1": 0}
+	})
+
+	at := time.Date(2024, time.March, 5, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		period StatsPeriod
+		path   string
+		params map[string]interface{}
+		first  time.Time
+	}{
+		{StatsHourly, "/dns/statistics-hourly.json", map[string]interface{}{"year": 2024.0, "month": 3.0, "day": 5.0}, time.Date(2024, time.March, 5, 1, 0, 0, 0, time.UTC)},
+		{StatsDaily, "/dns/statistics-daily.json", map[string]interface{}{"year": 2024.0, "month": 3.0}, time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)},
+		{StatsMonthly, "/dns/statistics-monthly.json", map[string]interface{}{"year": 2024.0}, time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)},
+		{StatsYearly, "/dns/statistics-yearly.json", map[string]interface{}{}, time.Date(1, time.January, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			stats, err := Zone{Domain: "testzone.bg"}.Statistics(context.Background(), testApiAccess, tt.period, at)
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if sentpath != tt.path {
+				t.Errorf("Expected path %s, got %s", tt.path, sentpath)
+			}
+			for _, k := range []string{"year", "month", "day"} {
+				if sent[k] != tt.params[k] {
+					t.Errorf("Expected %s %v, got %v", k, tt.params[k], sent[k])
+				}
+			}
+			if len(stats) != 3 || !stats[0].Time.Equal(tt.first) || stats[1].Queries != 5 || stats[2].Queries != 7 {
+				t.Errorf("Unexpected statistics %+v", stats)
+			}
+		})
+	}
+
+	if _, err := (Zone{Domain: "testzone.bg"}).Statistics(context.Background(), testApiAccess, 0, at); err == nil {
+		t.Errorf("Expected an error for an unknown period")
+	}
+}